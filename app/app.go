@@ -0,0 +1,169 @@
+// Package app describes what a runner drives: the apps, how to configure them and the demos
+// registered by name. It does not depend on GLFW, so apps can be rendered offscreen by the
+// headless runner as well as in a window by the window runner.
+package app
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/thegrandpackard/gogl/controls"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// App - Something the runner drives
+type App interface {
+	// Init - Creates GL resources. The context is current and the window exists.
+	Init() error
+
+	// Update - Advances the simulation by dt seconds
+	Update(dt float64)
+
+	// Render - Draws a frame. alpha is the fraction of a time step the clock has run ahead of the
+	// simulation; apps that interpolate blend from the state before the last Update (0) to the
+	// state after it (1).
+	Render(alpha float64)
+
+	// Shutdown - Releases everything Init created, even when Init failed halfway
+	Shutdown()
+}
+
+// WindowApp - Implemented by apps that read input from the window
+type WindowApp interface {
+	App
+	SetWindow(window controls.Window)
+}
+
+// HotkeyApp - Implemented by apps with hotkeys of their own, which the runner calls with every
+// key pressed in the window
+type HotkeyApp interface {
+	App
+	Hotkey(key controls.Key)
+}
+
+// InputApp - Implemented by apps that read input through a device the runner polls before every
+// Update, which is what can be recorded and replayed
+type InputApp interface {
+	App
+	SetInput(device *controls.Device)
+}
+
+// ProgramCacheUser - Implemented by apps that can link their programs through a cache on disk
+type ProgramCacheUser interface {
+	SetProgramCache(cache *helpers.ProgramCache)
+}
+
+// Resizer - Implemented by apps whose rendering depends on the framebuffer size
+type Resizer interface {
+	// Resize - Called with the framebuffer size in pixels before Init and whenever it changes.
+	// On HiDPI displays it differs from the window size.
+	Resize(width, height int)
+}
+
+// Config - How the runner sets up the window and the loop
+type Config struct {
+	Title         string
+	Width, Height int
+	Samples       int
+	Profiles      []helpers.ContextProfile
+
+	// TimeStep - Seconds simulated by every Update. Zero updates once per frame with the frame time.
+	TimeStep float64
+
+	// MaxUpdates - Most updates run for one frame. A slower simulation falls behind instead of
+	// spending ever longer catching up.
+	MaxUpdates int
+
+	Display Display
+
+	// GamepadMappings - An SDL_GameControllerDB file with mappings for joysticks GLFW does not
+	// lay out like an Xbox 360 controller
+	GamepadMappings string
+
+	// Record - A file to record the input of every update to. Only what apps read through their
+	// device is recorded, not the runner's own hotkeys like switching demos or going fullscreen.
+	Record string
+
+	// Replay - A recording to play back instead of the window's input. Its time step replaces
+	// TimeStep, so every update sees the same input it was recorded with.
+	Replay string
+
+	// ProgramCache - Directory linked programs are kept in so later launches skip compiling and
+	// linking them, empty to always link
+	ProgramCache string
+
+	// State - When set the window is placed where it was last time, and its placement is kept
+	// there on exit
+	State *State
+
+	// Capture - A .gif file, or a .png file numbered per frame, to capture the first
+	// CaptureFrames frames to before exiting. Each of them runs one update of TimeStep.
+	Capture       string
+	CaptureFrames int
+
+	// ScreenshotDir - Where F12 saves screenshots and Shift+F12 captures
+	ScreenshotDir string
+}
+
+// DefaultConfig - A vsynced 1024x768 window with 4x MSAA, updated 60 times per second
+func DefaultConfig(title string) Config {
+	return Config{
+		Title:      title,
+		Width:      1024,
+		Height:     768,
+		Samples:    4,
+		Profiles:   helpers.DefaultProfiles,
+		TimeStep:   1.0 / 60,
+		MaxUpdates: 5,
+		Display:    Display{VSync: true},
+
+		ProgramCache: helpers.DefaultProgramCacheDir(),
+
+		CaptureFrames: 120,
+		ScreenshotDir: ".",
+	}
+}
+
+// RegisterFlags - Adds the settings that can be changed from the command line to flags
+func (c *Config) RegisterFlags(flags *flag.FlagSet) {
+	flags.IntVar(&c.Width, "width", c.Width, "window width")
+	flags.IntVar(&c.Height, "height", c.Height, "window height")
+	flags.IntVar(&c.Samples, "samples", c.Samples, "MSAA samples, 0 to disable")
+	flags.Var(profilesFlag{&c.Profiles}, "gl", "OpenGL version to request as MAJOR.MINOR instead of trying the defaults")
+	c.Display.RegisterFlags(flags)
+	flags.StringVar(&c.GamepadMappings, "gamepad-mappings", c.GamepadMappings, "SDL_GameControllerDB `file` of gamepad mappings")
+	flags.StringVar(&c.Record, "record", c.Record, "record the input to `file`")
+	flags.StringVar(&c.Replay, "replay", c.Replay, "replay the input recorded in `file`")
+	flags.StringVar(&c.ProgramCache, "program-cache", c.ProgramCache, "`directory` of linked programs kept between launches, empty to always link")
+	flags.StringVar(&c.Capture, "capture", c.Capture, "capture the first frames to `file`.gif or numbered file.png and exit")
+	flags.IntVar(&c.CaptureFrames, "capture-frames", c.CaptureFrames, "frames captured by -capture and Shift+F12")
+	flags.StringVar(&c.ScreenshotDir, "screenshots", c.ScreenshotDir, "`directory` of screenshots and captures")
+}
+
+// profilesFlag - Replaces the profiles with the single GL version given as MAJOR.MINOR
+type profilesFlag struct {
+	profiles *[]helpers.ContextProfile
+}
+
+func (f profilesFlag) String() string {
+	if f.profiles == nil {
+		return ""
+	}
+	names := make([]string, len(*f.profiles))
+	for i, profile := range *f.profiles {
+		names[i] = profile.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+func (f profilesFlag) Set(value string) error {
+	var profile helpers.ContextProfile
+	if _, err := fmt.Sscanf(value, "%d.%d", &profile.Major, &profile.Minor); err != nil {
+		return fmt.Errorf("invalid GL version %q, expected MAJOR.MINOR", value)
+	}
+	// Core profiles exist from 3.2 on
+	profile.Core = profile.Major > 3 || profile.Major == 3 && profile.Minor >= 2
+	*f.profiles = []helpers.ContextProfile{profile}
+	return nil
+}
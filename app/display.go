@@ -0,0 +1,95 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// FullscreenMode - How the window covers a monitor
+type FullscreenMode int
+
+// Fullscreen modes
+const (
+	// Windowed - A regular decorated window
+	Windowed FullscreenMode = iota
+	// Borderless - Covers the monitor at its current video mode, so switching is quick
+	Borderless
+	// Exclusive - Takes the monitor over and switches it to the configured video mode
+	Exclusive
+)
+
+var fullscreenModeNames = []string{"windowed", "borderless", "exclusive"}
+
+func (m FullscreenMode) String() string {
+	if m < 0 || int(m) >= len(fullscreenModeNames) {
+		return fmt.Sprintf("FullscreenMode(%d)", int(m))
+	}
+	return fullscreenModeNames[m]
+}
+
+// Set - Parses windowed, borderless or exclusive, for use as a flag
+func (m *FullscreenMode) Set(value string) error {
+	for i, name := range fullscreenModeNames {
+		if strings.EqualFold(value, name) {
+			*m = FullscreenMode(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown fullscreen mode %q, expected one of %s", value, strings.Join(fullscreenModeNames, ", "))
+}
+
+// VideoMode - A monitor resolution and refresh rate. Zero fields keep the monitor's current value.
+type VideoMode struct {
+	Width, Height int
+	RefreshRate   int
+}
+
+func (v VideoMode) String() string {
+	if v.Width == 0 && v.Height == 0 && v.RefreshRate == 0 {
+		return "current"
+	}
+	return fmt.Sprintf("%dx%d@%d", v.Width, v.Height, v.RefreshRate)
+}
+
+// Set - Parses WIDTHxHEIGHT or WIDTHxHEIGHT@HZ, for use as a flag
+func (v *VideoMode) Set(value string) error {
+	var mode VideoMode
+	if value == "" || value == "current" {
+		*v = mode
+		return nil
+	}
+	if _, err := fmt.Sscanf(value, "%dx%d@%d", &mode.Width, &mode.Height, &mode.RefreshRate); err != nil {
+		mode.RefreshRate = 0
+		if _, err := fmt.Sscanf(value, "%dx%d", &mode.Width, &mode.Height); err != nil {
+			return fmt.Errorf("invalid video mode %q, expected WIDTHxHEIGHT[@HZ]", value)
+		}
+	}
+	*v = mode
+	return nil
+}
+
+// Display - Fullscreen, monitor and frame pacing settings
+type Display struct {
+	Fullscreen FullscreenMode
+
+	// Monitor - Index into the connected monitors, 0 is the primary one
+	Monitor int
+
+	// VideoMode - Used by exclusive fullscreen, the zero value keeps the desktop mode
+	VideoMode VideoMode
+
+	VSync bool
+
+	// FrameCap - Most frames rendered per second, zero for no limit
+	FrameCap float64
+}
+
+// RegisterFlags - Adds the display settings to flags, with the current values as defaults
+func (d *Display) RegisterFlags(flags *flag.FlagSet) {
+	flags.Var(&d.Fullscreen, "fullscreen", "windowed, borderless or exclusive")
+	flags.IntVar(&d.Monitor, "monitor", d.Monitor, "index of the monitor to go fullscreen on")
+	flags.Var(&d.VideoMode, "video-mode", "video mode of exclusive fullscreen as WIDTHxHEIGHT[@HZ]")
+	flags.BoolVar(&d.VSync, "vsync", d.VSync, "wait for vertical blank before swapping")
+	flags.Float64Var(&d.FrameCap, "fps-cap", d.FrameCap, "most frames per second, 0 for no limit")
+}
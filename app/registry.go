@@ -0,0 +1,61 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Demo - A registered app the launcher can run by name
+type Demo struct {
+	Name        string
+	Title       string
+	Description string
+
+	// Assets - Folder below the asset root holding the demo's textures and models
+	Assets string
+
+	// New - Returns the app for config, loading its assets from assetDir
+	New func(config Config, assetDir string) App
+}
+
+var registry = struct {
+	sync.Mutex
+	demos map[string]Demo
+}{demos: make(map[string]Demo)}
+
+// Register - Makes demo available to the launcher. Demos call it from an init function; names
+// have to be unique.
+func Register(demo Demo) {
+	if demo.Name == "" || demo.New == nil {
+		panic("app: Register needs a demo with a name and a constructor")
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.demos[demo.Name]; ok {
+		panic(fmt.Sprintf("app: demo %q registered twice", demo.Name))
+	}
+	registry.demos[demo.Name] = demo
+}
+
+// Demos - Returns every registered demo sorted by name
+func Demos() []Demo {
+	registry.Lock()
+	defer registry.Unlock()
+
+	demos := make([]Demo, 0, len(registry.demos))
+	for _, demo := range registry.demos {
+		demos = append(demos, demo)
+	}
+	sort.Slice(demos, func(i, j int) bool { return demos[i].Name < demos[j].Name })
+	return demos
+}
+
+// LookupDemo - Returns the demo registered as name
+func LookupDemo(name string) (Demo, bool) {
+	registry.Lock()
+	defer registry.Unlock()
+	demo, ok := registry.demos[name]
+	return demo, ok
+}
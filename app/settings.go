@@ -0,0 +1,247 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Configurable - Implemented by apps with settings that can come from a config file.
+// Settings returns a pointer to a struct whose fields carry json tags naming the keys; it is
+// filled in after the app is created and before Init.
+type Configurable interface {
+	Settings() interface{}
+}
+
+// Validator - Implemented by settings that check their values. Errors start with the offending
+// key, like "speed: must not be negative".
+type Validator interface {
+	Validate() error
+}
+
+// SettingsFile - Settings per demo, keyed by demo name and then setting key:
+//
+//	{
+//		"cube_keyboard_mouse": {"speed": 5, "clearColor": [0, 0, 0, 1]}
+//	}
+type SettingsFile map[string]map[string]json.RawMessage
+
+// LoadSettings - Reads a settings file
+func LoadSettings(file string) (SettingsFile, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %v", err)
+	}
+
+	var settings SettingsFile
+	if err := json.Unmarshal(data, &settings); err != nil {
+		if syntaxErr, ok := err.(*json.SyntaxError); ok {
+			line := bytes.Count(data[:syntaxErr.Offset], []byte("\n")) + 1
+			return nil, fmt.Errorf("%s:%d: %v", file, line, err)
+		}
+		if typeErr, ok := err.(*json.UnmarshalTypeError); ok && typeErr.Field != "" {
+			return nil, fmt.Errorf("%s: %s: expected an object of settings, got %s", file, typeErr.Field, typeErr.Value)
+		}
+		return nil, fmt.Errorf("%s: every top level key has to be a demo name holding an object of settings: %v", file, err)
+	}
+	return settings, nil
+}
+
+// Set - Applies an override of the form demo.key=value. Values are JSON, anything that does
+// not parse as JSON is taken as a string.
+func (f SettingsFile) Set(override string) error {
+	equals := strings.Index(override, "=")
+	dot := strings.Index(override, ".")
+	if equals < 0 || dot < 0 || dot > equals {
+		return fmt.Errorf("invalid setting %q, expected demo.key=value", override)
+	}
+	demo, key, value := override[:dot], override[dot+1:equals], override[equals+1:]
+
+	raw := json.RawMessage(value)
+	if !json.Valid(raw) {
+		raw, _ = json.Marshal(value)
+	}
+	if f[demo] == nil {
+		f[demo] = make(map[string]json.RawMessage)
+	}
+	f[demo][key] = raw
+	return nil
+}
+
+// Check - Applies every section to a fresh instance of its demo, so mistakes anywhere in the
+// file are reported at startup rather than when a demo is first shown
+func (f SettingsFile) Check(config Config) error {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		demo, ok := LookupDemo(name)
+		if !ok {
+			return fmt.Errorf("%s: no such demo", name)
+		}
+		if err := f.Apply(name, demo.New(config, "")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply - Sets the settings of demo on app and validates the result. Errors name the bad key
+// as demo.key.
+func (f SettingsFile) Apply(demo string, app App) error {
+	configurable, ok := app.(Configurable)
+	if !ok {
+		if len(f[demo]) > 0 {
+			return fmt.Errorf("%s: the demo has no settings", demo)
+		}
+		return nil
+	}
+	settings := configurable.Settings()
+
+	keys := make([]string, 0, len(f[demo]))
+	for key := range f[demo] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	target := reflect.ValueOf(settings).Elem()
+	for _, key := range keys {
+		field, ok := settingField(target, key)
+		if !ok {
+			return fmt.Errorf("%s.%s: unknown setting, expected one of %s", demo, key, strings.Join(settingKeys(target.Type()), ", "))
+		}
+		if err := decodeSetting(f[demo][key], field); err != nil {
+			return fmt.Errorf("%s.%s: %v", demo, key, err)
+		}
+	}
+
+	if validator, ok := settings.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("%s.%v", demo, err)
+		}
+	}
+	return nil
+}
+
+// DumpSettings - Returns the effective settings of app as JSON
+func DumpSettings(app App) string {
+	configurable, ok := app.(Configurable)
+	if !ok {
+		return "{}"
+	}
+	data, err := json.Marshal(configurable.Settings())
+	if err != nil {
+		return fmt.Sprintf("<%v>", err)
+	}
+	return string(data)
+}
+
+// settingField - Finds the field tagged key, looking into embedded structs
+func settingField(v reflect.Value, key string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if found, ok := settingField(v.Field(i), key); ok {
+				return found, true
+			}
+			continue
+		}
+		if tagName(field) == key && field.PkgPath == "" {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func settingKeys(t reflect.Type) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			keys = append(keys, settingKeys(field.Type)...)
+		} else if name := tagName(field); name != "" && field.PkgPath == "" {
+			keys = append(keys, name)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func tagName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// decodeSetting - Unmarshals raw into field with errors that make sense without the Go types
+func decodeSetting(raw json.RawMessage, field reflect.Value) error {
+	// encoding/json silently pads or truncates arrays
+	if field.Kind() == reflect.Array {
+		var elements []json.RawMessage
+		if err := json.Unmarshal(raw, &elements); err != nil || len(elements) != field.Len() {
+			return fmt.Errorf("expected %s, got %s", describe(field.Type()), raw)
+		}
+	}
+
+	value := reflect.New(field.Type())
+	// Objects decode over a copy of the defaults, so they only have to name what they change
+	if field.Kind() == reflect.Map && !field.IsNil() {
+		value.Elem().Set(reflect.MakeMapWithSize(field.Type(), field.Len()))
+		for _, key := range field.MapKeys() {
+			value.Elem().SetMapIndex(key, field.MapIndex(key))
+		}
+	}
+	if err := json.Unmarshal(raw, value.Interface()); err != nil {
+		return fmt.Errorf("expected %s, got %s", describe(field.Type()), raw)
+	}
+	field.Set(value.Elem())
+	return nil
+}
+
+func describe(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Array:
+		return fmt.Sprintf("an array of %d %s", t.Len(), plural(t.Elem()))
+	case reflect.Slice:
+		return "an array of " + plural(t.Elem())
+	case reflect.Map:
+		return "an object of " + plural(t.Elem())
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Bool:
+		return "true or false"
+	case reflect.String:
+		return "a string"
+	}
+	return t.String()
+}
+
+func plural(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return "numbers"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integers"
+	case reflect.Bool:
+		return "booleans"
+	case reflect.String:
+		return "strings"
+	case reflect.Slice:
+		return "arrays of " + plural(t.Elem())
+	}
+	return "values of " + describe(t)
+}
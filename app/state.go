@@ -0,0 +1,118 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// StatefulApp - Implemented by apps that keep something between launches, like where their
+// camera was
+type StatefulApp interface {
+	App
+
+	// SaveState - What to keep, called before Shutdown
+	SaveState() (json.RawMessage, error)
+
+	// RestoreState - Called with what SaveState returned last time after the settings are
+	// applied and before Init
+	RestoreState(state json.RawMessage) error
+}
+
+// State - What is kept between launches in a per-user file: where the window was, the demo that
+// ran last and what every demo kept
+type State struct {
+	Demo   string                     `json:"demo,omitempty"`
+	Window *WindowState               `json:"window,omitempty"`
+	Demos  map[string]json.RawMessage `json:"demos,omitempty"`
+}
+
+// WindowState - The placement of the window, in screen coordinates. A fullscreen window keeps
+// the placement it returns to.
+type WindowState struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// DefaultStateFile - gogl/state.json in the user's configuration directory, or nothing when the
+// system has none
+func DefaultStateFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gogl", "state.json")
+}
+
+// LoadState - Reads a state file. One that does not exist yet is an empty state.
+func LoadState(file string) (*State, error) {
+	state := &State{}
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	return state, nil
+}
+
+// Save - Writes the state, replacing the file only once it is complete
+func (s *State) Save(file string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	temp := file + ".tmp"
+	if err := ioutil.WriteFile(temp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(temp, file)
+}
+
+// ApplyWindowSize - Makes config open the window at its last size
+func (s *State) ApplyWindowSize(config *Config) {
+	if s.Window != nil && s.Window.Width > 0 && s.Window.Height > 0 {
+		config.Width, config.Height = s.Window.Width, s.Window.Height
+	}
+}
+
+// saveApp - Keeps what app wants kept under name
+func (s *State) saveApp(name string, app App) {
+	stateful, ok := app.(StatefulApp)
+	if s == nil || !ok {
+		return
+	}
+	state, err := stateful.SaveState()
+	if err != nil {
+		log.Printf("failed to keep the state of %s: %v", name, err)
+		return
+	}
+	if s.Demos == nil {
+		s.Demos = make(map[string]json.RawMessage)
+	}
+	s.Demos[name] = state
+}
+
+// restoreApp - Hands app what was kept under name. State that no longer fits is dropped, it is
+// not worth failing to start over.
+func (s *State) restoreApp(name string, app App) {
+	stateful, ok := app.(StatefulApp)
+	if s == nil || !ok || s.Demos[name] == nil {
+		return
+	}
+	if err := stateful.RestoreState(s.Demos[name]); err != nil {
+		log.Printf("ignoring the kept state of %s: %v", name, err)
+	}
+}
@@ -0,0 +1,222 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/thegrandpackard/gogl/controls"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// TextureUser - Implemented by apps that can load their textures through a shared cache
+type TextureUser interface {
+	SetTextureCache(cache *helpers.TextureCache)
+}
+
+// Switcher - Runs one of several demos at a time in the same window and context, switching
+// between them with hotkeys:
+//
+//	Page Down  next demo
+//	Page Up    previous demo
+//	F1         list the demos
+//
+// Every switch shuts the running demo down and initializes the next one from scratch. Textures
+// go through a cache that lives as long as the switcher, so shared images stay loaded.
+type Switcher struct {
+	// Settings - Applied to every demo before it is initialized
+	Settings SettingsFile
+
+	// State - When set every demo gets back what it kept the last time it ran, and the demo
+	// running last is remembered
+	State *State
+
+	demos     []Demo
+	current   int
+	app       App
+	config    Config
+	assetRoot string
+	textures  *helpers.TextureCache
+	programs  *helpers.ProgramCache
+
+	window        controls.Window
+	device        *controls.Device
+	width, height int
+}
+
+// NewSwitcher - Returns a switcher over demos starting with the one named start, loading assets
+// from below assetRoot
+func NewSwitcher(demos []Demo, start string, config Config, assetRoot string) (*Switcher, error) {
+	if len(demos) == 0 {
+		return nil, fmt.Errorf("no demos to switch between")
+	}
+
+	s := &Switcher{demos: demos, config: config, assetRoot: assetRoot, width: config.Width, height: config.Height}
+	if start != "" {
+		s.current = -1
+		for i, demo := range demos {
+			if demo.Name == start {
+				s.current = i
+			}
+		}
+		if s.current < 0 {
+			return nil, fmt.Errorf("unknown demo %q", start)
+		}
+	}
+	return s, nil
+}
+
+// Current - Returns the demo that is running
+func (s *Switcher) Current() Demo {
+	return s.demos[s.current]
+}
+
+// Title - The window title of the running demo
+func (s *Switcher) Title() string {
+	demo := s.Current()
+	return fmt.Sprintf("%s (%s)", demo.Title, demo.Name)
+}
+
+// SetWindow - Hands window to every demo that reads input from it
+func (s *Switcher) SetWindow(window controls.Window) {
+	s.window = window
+}
+
+// SetInput - Hands device to every demo that reads input through one
+func (s *Switcher) SetInput(device *controls.Device) {
+	s.device = device
+}
+
+// SetProgramCache - Hands cache to every demo that links its programs through one
+func (s *Switcher) SetProgramCache(cache *helpers.ProgramCache) {
+	s.programs = cache
+}
+
+// Resize - Passes the framebuffer size on to the running demo and remembers it for the next
+func (s *Switcher) Resize(width, height int) {
+	s.width, s.height = width, height
+	if resizer, ok := s.app.(Resizer); ok {
+		resizer.Resize(width, height)
+	}
+}
+
+// Init - Creates the texture cache and initializes the first demo
+func (s *Switcher) Init() error {
+	s.textures = helpers.NewTextureCache()
+	return s.start(s.current)
+}
+
+// start - Creates and initializes demo i. When that fails whatever it created is released again.
+func (s *Switcher) start(i int) error {
+	demo := s.demos[i]
+	config := s.config
+	config.Title = demo.Title
+	config.Width, config.Height = s.width, s.height
+
+	app := demo.New(config, filepath.Join(s.assetRoot, demo.Assets))
+	if windowApp, ok := app.(WindowApp); ok && s.window != nil {
+		windowApp.SetWindow(s.window)
+	}
+	if inputApp, ok := app.(InputApp); ok && s.device != nil {
+		inputApp.SetInput(s.device)
+	}
+	if textureUser, ok := app.(TextureUser); ok {
+		textureUser.SetTextureCache(s.textures)
+	}
+	if cacheUser, ok := app.(ProgramCacheUser); ok && s.programs != nil {
+		cacheUser.SetProgramCache(s.programs)
+	}
+	if resizer, ok := app.(Resizer); ok {
+		resizer.Resize(s.width, s.height)
+	}
+	if err := s.Settings.Apply(demo.Name, app); err != nil {
+		return err
+	}
+	log.Printf("%s settings: %s", demo.Name, DumpSettings(app))
+	s.State.restoreApp(demo.Name, app)
+
+	if err := app.Init(); err != nil {
+		app.Shutdown()
+		return fmt.Errorf("failed to start %s: %v", demo.Name, err)
+	}
+
+	s.app, s.current = app, i
+	if s.State != nil {
+		s.State.Demo = demo.Name
+	}
+	return nil
+}
+
+// Switch - Shuts the running demo down and starts demo i instead. If demo i fails to start the
+// previous one is started again.
+func (s *Switcher) Switch(i int) error {
+	i = (i%len(s.demos) + len(s.demos)) % len(s.demos)
+	if i == s.current && s.app != nil {
+		return nil
+	}
+
+	previous := s.current
+	if s.app != nil {
+		s.State.saveApp(s.demos[s.current].Name, s.app)
+		s.app.Shutdown()
+		s.app = nil
+	}
+
+	if err := s.start(i); err != nil {
+		if restartErr := s.start(previous); restartErr != nil {
+			return fmt.Errorf("%v, and restarting %s failed: %v", err, s.demos[previous].Name, restartErr)
+		}
+		return err
+	}
+
+	log.Printf("switched to %s, %d texture(s) cached", s.demos[i].Name, s.textures.Len())
+	return nil
+}
+
+// Hotkey - Switches demos or lists them
+func (s *Switcher) Hotkey(key controls.Key) {
+	var err error
+	switch key {
+	case controls.KeyPageDown:
+		err = s.Switch(s.current + 1)
+	case controls.KeyPageUp:
+		err = s.Switch(s.current - 1)
+	case controls.KeyF1:
+		for i, demo := range s.demos {
+			marker := " "
+			if i == s.current {
+				marker = "*"
+			}
+			fmt.Printf("%s %-20s %s\n", marker, demo.Name, demo.Description)
+		}
+	}
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// Update - Updates the running demo
+func (s *Switcher) Update(dt float64) {
+	if s.app != nil {
+		s.app.Update(dt)
+	}
+}
+
+// Render - Renders the running demo
+func (s *Switcher) Render(alpha float64) {
+	if s.app != nil {
+		s.app.Render(alpha)
+	}
+}
+
+// Shutdown - Shuts the running demo down and releases the cached textures
+func (s *Switcher) Shutdown() {
+	if s.app != nil {
+		s.State.saveApp(s.demos[s.current].Name, s.app)
+		s.app.Shutdown()
+		s.app = nil
+	}
+	if s.textures != nil {
+		s.textures.Delete()
+	}
+}
@@ -0,0 +1,226 @@
+// Package camera moves cameras from snapshots of the input, without reading a window itself, so
+// the motion does not depend on where the input comes from.
+package camera
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Input - What moved since the last update, gathered by whoever owns the window
+type Input struct {
+	Forward, Backward, Left, Right bool
+
+	// LookX, LookY - Cursor movement in pixels, positive to the right and down
+	LookX, LookY float64
+
+	// Zoom - Scroll wheel notches, positive away from the user
+	Zoom float64
+
+	// Rotating, Panning - Whether the cursor movement drags the view around or sideways, for
+	// cameras that are not steered by the cursor all the time
+	Rotating, Panning bool
+
+	// MoveX, MoveY, TurnX, TurnY - Analog sticks from -1 to 1, moving right and forward and
+	// turning right and down, on top of the keys and the cursor
+	MoveX, MoveY float64
+	TurnX, TurnY float64
+
+	// ZoomAxis - Analog zoom from -1 to 1, positive like Zoom, full deflection counting as
+	// ZoomAxisRate scroll wheel notches per second
+	ZoomAxis float64
+}
+
+// ZoomAxisRate - Scroll wheel notches per second of a fully deflected ZoomAxis
+const ZoomAxisRate = 4
+
+// zoom - The scroll wheel notches of input over dt seconds
+func (input Input) zoom(dt float64) float64 {
+	return input.Zoom + input.ZoomAxis*ZoomAxisRate*dt
+}
+
+// axis - A direction from keys held for either end and an analog value, kept within -1 to 1
+func axis(positive, negative bool, analog float64) float64 {
+	if positive {
+		analog++
+	}
+	if negative {
+		analog--
+	}
+	return math.Max(-1, math.Min(1, analog))
+}
+
+// Controller - A camera that input moves and that can be rendered from
+type Controller interface {
+	Update(input Input, dt float64)
+	InterpolatedView(alpha float64) mgl32.Mat4
+	Projection(aspect float32) mgl32.Mat4
+
+	// Pose - Where the camera is and the unit vector it looks along
+	Pose() (position, direction mgl32.Vec3)
+	// SetPose - Moves the camera without motion, so switching controllers keeps the view
+	SetPose(position, direction mgl32.Vec3)
+}
+
+// Camera - A first person camera that flies where it looks. Its orientation is a yaw around the
+// world's up axis followed by a pitch around the camera's own horizontal axis, composed as
+// quaternions, with the pitch kept short of straight up or down so the view never flips.
+type Camera struct {
+	Position mgl32.Vec3
+
+	// HorizontalAngle, VerticalAngle - Radians, looking along +Z at 0, 0 and along -Z at Pi, 0.
+	// VerticalAngle is clamped to MaxPitch either way.
+	HorizontalAngle, VerticalAngle float64
+
+	// FieldOfView - Vertical, in degrees
+	FieldOfView float32
+	Near, Far   float32
+
+	// Speed - Units per second
+	Speed float64
+
+	// Sensitivity - Degrees turned per pixel of cursor movement, independent of the frame rate
+	Sensitivity float64
+
+	// TurnSpeed - Degrees per second turned by a fully deflected analog stick
+	TurnSpeed float64
+
+	// Smoothing - Seconds for the cursor movement still to be applied to shrink to about a third,
+	// zero to turn immediately
+	Smoothing float64
+
+	// ZoomSpeed - Degrees of field of view per scroll wheel notch
+	ZoomSpeed float64
+
+	// look is cursor movement smoothing has not applied yet
+	lookX, lookY float64
+
+	previousPosition    mgl32.Vec3
+	previousOrientation mgl32.Quat
+}
+
+// Field of view limits of zooming
+const (
+	MinFieldOfView = 1
+	MaxFieldOfView = 179
+)
+
+// MaxPitch - Radians, just short of straight up or down, where the view would flip
+const MaxPitch = math.Pi/2 - 0.01
+
+func clampPitch(pitch float64) float64 {
+	return math.Max(-MaxPitch, math.Min(MaxPitch, pitch))
+}
+
+// Update - Advances the camera by dt seconds of input
+func (c *Camera) Update(input Input, dt float64) {
+	c.previousPosition, c.previousOrientation = c.Position, c.Orientation()
+
+	// The cursor moves the same number of pixels whatever the frame rate, so the look is
+	// not scaled by dt. Smoothing hands the movement out over the following updates by a
+	// fraction that only depends on dt, so the total turn stays the same.
+	c.lookX += input.LookX
+	c.lookY += input.LookY
+	t := 1.0
+	if c.Smoothing > 0 {
+		t = 1 - math.Exp(-dt/c.Smoothing)
+	}
+	lookX, lookY := c.lookX*t, c.lookY*t
+	c.lookX -= lookX
+	c.lookY -= lookY
+
+	// Sticks turn at a rate, so unlike the cursor they are scaled by dt
+	turnX := c.Sensitivity*lookX + c.TurnSpeed*input.TurnX*dt
+	turnY := c.Sensitivity*lookY + c.TurnSpeed*input.TurnY*dt
+	c.HorizontalAngle -= turnX * math.Pi / 180
+	c.VerticalAngle = clampPitch(c.VerticalAngle - turnY*math.Pi/180)
+
+	step := dt * c.Speed
+	forward := float32(step * axis(input.Forward, input.Backward, input.MoveY))
+	sideways := float32(step * axis(input.Right, input.Left, input.MoveX))
+	c.Position = c.Position.Add(c.Direction().Mul(forward)).Add(c.Right().Mul(sideways))
+
+	fieldOfView := float64(c.FieldOfView) - c.ZoomSpeed*input.zoom(dt)
+	c.FieldOfView = float32(math.Max(MinFieldOfView, math.Min(MaxFieldOfView, fieldOfView)))
+}
+
+// Reset - Forgets the motion of the last update and any look still being smoothed, for cameras
+// that were moved directly
+func (c *Camera) Reset() {
+	c.VerticalAngle = clampPitch(c.VerticalAngle)
+	c.lookX, c.lookY = 0, 0
+	c.previousPosition, c.previousOrientation = c.Position, c.Orientation()
+}
+
+// Pose - Where the camera is and where it looks
+func (c *Camera) Pose() (mgl32.Vec3, mgl32.Vec3) {
+	return c.Position, c.Direction()
+}
+
+// SetPose - Places the camera at position looking along direction
+func (c *Camera) SetPose(position, direction mgl32.Vec3) {
+	c.Position = position
+	c.HorizontalAngle, c.VerticalAngle = angles(direction)
+	c.Reset()
+}
+
+// angles - The horizontal and vertical angle of direction, the inverse of Direction
+func angles(direction mgl32.Vec3) (float64, float64) {
+	direction = direction.Normalize()
+	return math.Atan2(float64(direction[0]), float64(direction[2])), math.Asin(float64(direction[1]))
+}
+
+// fromAngles - The unit vector of a horizontal and vertical angle
+func fromAngles(horizontal, vertical float64) mgl32.Vec3 {
+	return mgl32.Vec3{
+		float32(math.Cos(vertical) * math.Sin(horizontal)),
+		float32(math.Sin(vertical)),
+		float32(math.Cos(vertical) * math.Cos(horizontal)),
+	}
+}
+
+// Orientation - The rotation from looking along +Z with +Y up to the camera's view
+func (c *Camera) Orientation() mgl32.Quat {
+	return yawPitch(c.HorizontalAngle, c.VerticalAngle)
+}
+
+func yawPitch(horizontal, vertical float64) mgl32.Quat {
+	yaw := mgl32.QuatRotate(float32(horizontal), mgl32.Vec3{0, 1, 0})
+	pitch := mgl32.QuatRotate(float32(-vertical), mgl32.Vec3{1, 0, 0})
+	return yaw.Mul(pitch)
+}
+
+// Direction - The unit vector the camera looks along
+func (c *Camera) Direction() mgl32.Vec3 {
+	return c.Orientation().Rotate(mgl32.Vec3{0, 0, 1})
+}
+
+// Right - The horizontal unit vector to the right of the view
+func (c *Camera) Right() mgl32.Vec3 {
+	return c.Orientation().Rotate(mgl32.Vec3{-1, 0, 0})
+}
+
+// Up - The unit vector pointing up in the view
+func (c *Camera) Up() mgl32.Vec3 {
+	return c.Orientation().Rotate(mgl32.Vec3{0, 1, 0})
+}
+
+// View - The world to camera transform
+func (c *Camera) View() mgl32.Mat4 {
+	return c.InterpolatedView(1)
+}
+
+// InterpolatedView - The view alpha of the way from the pose before the last update to the
+// current one, for rendering between fixed updates
+func (c *Camera) InterpolatedView(alpha float64) mgl32.Mat4 {
+	position := c.previousPosition.Add(c.Position.Sub(c.previousPosition).Mul(float32(alpha)))
+	orientation := mgl32.QuatSlerp(c.previousOrientation, c.Orientation(), float32(alpha))
+	direction := orientation.Rotate(mgl32.Vec3{0, 0, 1})
+	return mgl32.LookAtV(position, position.Add(direction), orientation.Rotate(mgl32.Vec3{0, 1, 0}))
+}
+
+// Projection - The perspective projection for a target of the given aspect ratio
+func (c *Camera) Projection(aspect float32) mgl32.Mat4 {
+	return mgl32.Perspective(mgl32.DegToRad(c.FieldOfView), aspect, c.Near, c.Far)
+}
@@ -0,0 +1,223 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const epsilon = 1e-5
+
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+// near - Whether a and b differ by at most epsilon in every component. mathgl's ApproxEqual
+// compares relative to the values, which fails next to zero.
+func near(a, b []float32) bool {
+	for i := range a {
+		if math.Abs(float64(a[i]-b[i])) > epsilon {
+			return false
+		}
+	}
+	return true
+}
+
+func TestUpdateLook(t *testing.T) {
+	tests := []struct {
+		name                 string
+		input                Input
+		dt                   float64
+		horizontal, vertical float64
+	}{
+		// Cursor movement turns Sensitivity degrees per pixel, whatever the frame time
+		{"right", Input{LookX: 10}, 1.0 / 60, -1, 0},
+		{"left", Input{LookX: -25}, 1.0 / 60, 2.5, 0},
+		{"down", Input{LookY: 10}, 1.0 / 60, 0, -1},
+		{"up at 240 Hz", Input{LookY: -10}, 1.0 / 240, 0, 1},
+		{"right at 20 Hz", Input{LookX: 10}, 1.0 / 20, -1, 0},
+
+		// Sticks turn TurnSpeed degrees per second
+		{"stick right", Input{TurnX: 1}, 0.5, -45, 0},
+		{"stick half down", Input{TurnY: 0.5}, 0.5, 0, -22.5},
+		{"cursor and stick", Input{LookX: 10, TurnX: -1}, 0.1, 8, 0},
+	}
+	for _, test := range tests {
+		c := Camera{Sensitivity: 0.1, TurnSpeed: 90}
+		c.Update(test.input, test.dt)
+		if got := degrees(c.HorizontalAngle); math.Abs(got-test.horizontal) > epsilon {
+			t.Errorf("%s: horizontal angle is %v°, want %v°", test.name, got, test.horizontal)
+		}
+		if got := degrees(c.VerticalAngle); math.Abs(got-test.vertical) > epsilon {
+			t.Errorf("%s: vertical angle is %v°, want %v°", test.name, got, test.vertical)
+		}
+	}
+}
+
+func TestUpdateMove(t *testing.T) {
+	tests := []struct {
+		name                 string
+		horizontal, vertical float64
+		input                Input
+		want                 mgl32.Vec3
+	}{
+		// Looking along +Z, the right of the view is -X
+		{"forward", 0, 0, Input{Forward: true}, mgl32.Vec3{0, 0, 1}},
+		{"backward", 0, 0, Input{Backward: true}, mgl32.Vec3{0, 0, -1}},
+		{"right", 0, 0, Input{Right: true}, mgl32.Vec3{-1, 0, 0}},
+		{"left", 0, 0, Input{Left: true}, mgl32.Vec3{1, 0, 0}},
+		{"forward and right", 0, 0, Input{Forward: true, Right: true}, mgl32.Vec3{-1, 0, 1}},
+		{"opposite keys", 0, 0, Input{Forward: true, Backward: true}, mgl32.Vec3{}},
+
+		// Movement follows the view, pitch included
+		{"forward turned", math.Pi / 2, 0, Input{Forward: true}, mgl32.Vec3{1, 0, 0}},
+		{"right turned", math.Pi / 2, 0, Input{Right: true}, mgl32.Vec3{0, 0, 1}},
+		{"forward pitched up", 0, math.Pi / 6, Input{Forward: true}, mgl32.Vec3{0, 0.5, float32(math.Sqrt(3) / 2)}},
+		{"right pitched up", 0, math.Pi / 6, Input{Right: true}, mgl32.Vec3{-1, 0, 0}},
+
+		// Sticks scale the step, keys and sticks together are kept to a full step
+		{"stick half forward", 0, 0, Input{MoveY: 0.5}, mgl32.Vec3{0, 0, 0.5}},
+		{"stick left", 0, 0, Input{MoveX: -1}, mgl32.Vec3{1, 0, 0}},
+		{"key and stick", 0, 0, Input{Forward: true, MoveY: 1}, mgl32.Vec3{0, 0, 1}},
+	}
+	for _, test := range tests {
+		// Two units per second for half a second is one unit
+		c := Camera{Position: mgl32.Vec3{1, 2, 3}, HorizontalAngle: test.horizontal, VerticalAngle: test.vertical, Speed: 2}
+		c.Update(test.input, 0.5)
+		if got := c.Position.Sub(mgl32.Vec3{1, 2, 3}); !near(got[:], test.want[:]) {
+			t.Errorf("%s: moved %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestUpdateZoom(t *testing.T) {
+	tests := []struct {
+		name  string
+		input Input
+		dt    float64
+		want  float32
+	}{
+		{"in", Input{Zoom: 1}, 1.0 / 60, 40},
+		{"out", Input{Zoom: -2}, 1.0 / 60, 55},
+		{"in past the limit", Input{Zoom: 100}, 1.0 / 60, MinFieldOfView},
+		{"out past the limit", Input{Zoom: -100}, 1.0 / 60, MaxFieldOfView},
+
+		// A full ZoomAxis is ZoomAxisRate notches per second
+		{"axis in", Input{ZoomAxis: 1}, 0.5, 45 - 5*ZoomAxisRate*0.5},
+		{"axis and wheel", Input{Zoom: 1, ZoomAxis: -0.5}, 0.5, 45 - 5 + 5*ZoomAxisRate*0.25},
+	}
+	for _, test := range tests {
+		c := Camera{FieldOfView: 45, ZoomSpeed: 5}
+		c.Update(test.input, test.dt)
+		if math.Abs(float64(c.FieldOfView-test.want)) > epsilon {
+			t.Errorf("%s: field of view is %v, want %v", test.name, c.FieldOfView, test.want)
+		}
+	}
+}
+
+func TestViewProjection(t *testing.T) {
+	c := Camera{
+		Position:        mgl32.Vec3{1, 2, 3},
+		HorizontalAngle: math.Pi / 2,
+		FieldOfView:     60,
+		Near:            0.1,
+		Far:             100,
+		Speed:           2,
+	}
+	c.Reset()
+	before := c.View()
+	c.Update(Input{Forward: true}, 0.5)
+
+	// Looking along +X from (2, 2, 3) after moving a unit forward
+	want := mgl32.LookAtV(mgl32.Vec3{2, 2, 3}, mgl32.Vec3{3, 2, 3}, mgl32.Vec3{0, 1, 0})
+	if got := c.View(); !near(got[:], want[:]) {
+		t.Errorf("view is %v, want %v", got, want)
+	}
+	// The view looks down its -Z axis, a point ahead of the camera ends up there
+	if got := mgl32.TransformCoordinate(mgl32.Vec3{5, 2, 3}, c.View()); !near(got[:], []float32{0, 0, -3}) {
+		t.Errorf("a point 3 units ahead is at %v in view space, want %v", got, mgl32.Vec3{0, 0, -3})
+	}
+
+	// Rendering between updates starts from the pose before the last one
+	if got := c.InterpolatedView(0); !near(got[:], before[:]) {
+		t.Errorf("view at alpha 0 is %v, want %v", got, before)
+	}
+	halfway := mgl32.LookAtV(mgl32.Vec3{1.5, 2, 3}, mgl32.Vec3{2.5, 2, 3}, mgl32.Vec3{0, 1, 0})
+	if got := c.InterpolatedView(0.5); !near(got[:], halfway[:]) {
+		t.Errorf("view at alpha 0.5 is %v, want %v", got, halfway)
+	}
+
+	for _, aspect := range []float32{1, 4.0 / 3, 16.0 / 9} {
+		want := mgl32.Perspective(mgl32.DegToRad(60), aspect, 0.1, 100)
+		if got := c.Projection(aspect); !near(got[:], want[:]) {
+			t.Errorf("projection at aspect %v is %v, want %v", aspect, got, want)
+		}
+	}
+}
+
+func TestSmoothingFrameRate(t *testing.T) {
+	// 100 pixels right and 40 up, either in one frame or spread over frames of varying length
+	tests := []struct {
+		name   string
+		frames [][3]float64 // LookX, LookY, dt
+	}{
+		{"one frame at 60 Hz", [][3]float64{{100, -40, 1.0 / 60}}},
+		{"one frame at 20 Hz", [][3]float64{{100, -40, 1.0 / 20}}},
+		{"four frames at 144 Hz", [][3]float64{{25, -10, 1.0 / 144}, {25, -10, 1.0 / 144}, {25, -10, 1.0 / 144}, {25, -10, 1.0 / 144}}},
+		{"uneven frames", [][3]float64{{10, 0, 1.0 / 240}, {60, -30, 1.0 / 30}, {0, -5, 0.1}, {30, -5, 1.0 / 90}}},
+	}
+	for _, test := range tests {
+		c := Camera{Sensitivity: 0.1, Smoothing: 0.05}
+		elapsed := 0.0
+		for _, frame := range test.frames {
+			c.Update(Input{LookX: frame[0], LookY: frame[1]}, frame[2])
+			elapsed += frame[2]
+		}
+		// Let smoothing settle, two seconds is 40 time constants
+		for ; elapsed < 2; elapsed += 1.0 / 60 {
+			c.Update(Input{}, 1.0/60)
+		}
+		if got := degrees(c.HorizontalAngle); math.Abs(got+10) > epsilon {
+			t.Errorf("%s: horizontal angle is %v°, want -10°", test.name, got)
+		}
+		if got := degrees(c.VerticalAngle); math.Abs(got-4) > epsilon {
+			t.Errorf("%s: vertical angle is %v°, want 4°", test.name, got)
+		}
+	}
+}
+
+func TestPitchLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		smoothing float64
+		frames    []Input
+		want      float64
+	}{
+		{"far up", 0, []Input{{LookY: -1e5}}, MaxPitch},
+		{"far down", 0, []Input{{LookY: 1e5}}, -MaxPitch},
+		{"far up smoothed", 0.05, []Input{{LookY: -1e5}}, MaxPitch},
+		{"up over many frames", 0, []Input{{LookY: -600}, {LookY: -600}, {LookY: -600}}, MaxPitch},
+		{"stick held down", 0, []Input{{TurnY: 1}, {TurnY: 1}, {TurnY: 1}}, -MaxPitch},
+
+		// Movement past the limit is dropped rather than owed, turning back starts at once
+		{"up and back", 0, []Input{{LookY: -1e5}, {LookY: 100}}, MaxPitch - 10*math.Pi/180},
+		{"down and back", 0, []Input{{LookY: 1e5}, {LookY: -100}}, -MaxPitch + 10*math.Pi/180},
+	}
+	for _, test := range tests {
+		c := Camera{Sensitivity: 0.1, TurnSpeed: 90, Smoothing: test.smoothing}
+		for _, input := range test.frames {
+			c.Update(input, 1)
+			if c.VerticalAngle > MaxPitch || c.VerticalAngle < -MaxPitch {
+				t.Errorf("%s: vertical angle %v is past %v", test.name, c.VerticalAngle, MaxPitch)
+			}
+		}
+		if math.Abs(c.VerticalAngle-test.want) > epsilon {
+			t.Errorf("%s: vertical angle is %v, want %v", test.name, c.VerticalAngle, test.want)
+		}
+		// The view never flips over, up stays above the horizon
+		if up := c.Up(); up[1] <= 0 {
+			t.Errorf("%s: up is %v", test.name, up)
+		}
+	}
+}
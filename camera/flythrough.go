@@ -0,0 +1,81 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Flythrough - A camera flying along a path instead of following input
+type Flythrough struct {
+	Path *Path
+
+	// Time - Seconds flown along the path
+	Time float64
+
+	// Loop - Starts over at the end instead of stopping at the last keyframe
+	Loop bool
+
+	// FieldOfView - Vertical, in degrees
+	FieldOfView float32
+	Near, Far   float32
+
+	previous, current Keyframe
+}
+
+// NewFlythrough - A flythrough at the start of path with a 45 degree field of view
+func NewFlythrough(path *Path) *Flythrough {
+	f := &Flythrough{Path: path, FieldOfView: 45, Near: 0.1, Far: 100}
+	f.Restart()
+	return f
+}
+
+// Restart - Jumps back to the first keyframe
+func (f *Flythrough) Restart() {
+	f.Time = 0
+	f.current = f.Path.At(0)
+	f.previous = f.current
+}
+
+// Done - Whether a flythrough that does not loop has reached the last keyframe
+func (f *Flythrough) Done() bool {
+	return !f.Loop && f.Time >= f.Path.Duration()
+}
+
+// Update - Flies dt seconds further, ignoring the input
+func (f *Flythrough) Update(input Input, dt float64) {
+	f.previous = f.current
+	f.Time += dt
+
+	duration := f.Path.Duration()
+	if f.Loop && duration > 0 && f.Time >= duration {
+		f.Time = math.Mod(f.Time, duration)
+		// Jump back instead of sweeping across the whole path in one frame
+		f.current = f.Path.At(f.Time)
+		f.previous = f.current
+		return
+	}
+	f.current = f.Path.At(f.Time)
+}
+
+// InterpolatedView - The view alpha of the way from the pose before the last update to the
+// current one
+func (f *Flythrough) InterpolatedView(alpha float64) mgl32.Mat4 {
+	position := f.previous.Position.Add(f.current.Position.Sub(f.previous.Position).Mul(float32(alpha)))
+	orientation := mgl32.QuatSlerp(f.previous.Orientation, f.current.Orientation, float32(alpha))
+	direction := orientation.Rotate(mgl32.Vec3{0, 0, 1})
+	return mgl32.LookAtV(position, position.Add(direction), orientation.Rotate(mgl32.Vec3{0, 1, 0}))
+}
+
+// Projection - The perspective projection for a target of the given aspect ratio
+func (f *Flythrough) Projection(aspect float32) mgl32.Mat4 {
+	return mgl32.Perspective(mgl32.DegToRad(f.FieldOfView), aspect, f.Near, f.Far)
+}
+
+// Pose - Where the path has the camera
+func (f *Flythrough) Pose() (mgl32.Vec3, mgl32.Vec3) {
+	return f.current.Position, f.current.Orientation.Rotate(mgl32.Vec3{0, 0, 1})
+}
+
+// SetPose - Has no effect, the path decides where the camera is
+func (f *Flythrough) SetPose(position, direction mgl32.Vec3) {}
@@ -0,0 +1,198 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Orbit - A camera circling a target for inspecting a model. Dragging rotates around the target
+// or pans it sideways, scrolling dollies towards it. Input moves a goal the camera eases
+// towards, so motion stops smoothly.
+type Orbit struct {
+	Target mgl32.Vec3
+	// Distance - From the target to the eye
+	Distance float64
+	// Yaw, Pitch - Radians, the direction from the eye to the target as for Camera's angles
+	Yaw, Pitch float64
+
+	FieldOfView float32
+	Near, Far   float32
+
+	// RotateSpeed - Radians per pixel dragged
+	RotateSpeed float64
+	// TurnSpeed - Radians per second of a fully deflected turning stick
+	TurnSpeed float64
+	// PanSpeed - Fraction of the distance moved per pixel dragged
+	PanSpeed float64
+	// DollySpeed - Fraction of the distance moved per scroll wheel notch
+	DollySpeed float64
+	// StickPanSpeed - Distances per second panned by a fully deflected moving stick
+	StickPanSpeed float64
+
+	MinDistance, MaxDistance float64
+
+	// Damping - Seconds for the remaining motion to shrink to about a third, zero to follow the
+	// input immediately
+	Damping float64
+
+	// goal is where the input wants the camera, previous where it was before the last update
+	goal, previous orbitPose
+}
+
+type orbitPose struct {
+	target     mgl32.Vec3
+	distance   float64
+	yaw, pitch float64
+}
+
+// NewOrbit - An orbit camera looking at target from distance with usual speeds
+func NewOrbit(target mgl32.Vec3, distance float64) *Orbit {
+	o := &Orbit{
+		Target:        target,
+		Distance:      distance,
+		FieldOfView:   45,
+		Near:          0.1,
+		Far:           1000,
+		RotateSpeed:   0.01,
+		TurnSpeed:     2,
+		PanSpeed:      0.002,
+		DollySpeed:    0.1,
+		StickPanSpeed: 1,
+		MinDistance:   0.1,
+		MaxDistance:   1000,
+		Damping:       0.1,
+	}
+	o.Reset()
+	return o
+}
+
+func (o *Orbit) pose() orbitPose {
+	return orbitPose{target: o.Target, distance: o.Distance, yaw: o.Yaw, pitch: o.Pitch}
+}
+
+// Reset - Stops any easing and forgets the motion of the last update, for orbits that were
+// moved directly
+func (o *Orbit) Reset() {
+	o.goal = o.pose()
+	o.previous = o.goal
+}
+
+// Settle - Jumps to the goal without easing
+func (o *Orbit) Settle() {
+	o.Target, o.Distance, o.Yaw, o.Pitch = o.goal.target, o.goal.distance, o.goal.yaw, o.goal.pitch
+	o.previous = o.goal
+}
+
+// Update - Moves the goal by dt seconds of input and eases the camera towards it
+func (o *Orbit) Update(input Input, dt float64) {
+	o.previous = o.pose()
+
+	// The turning stick drags the model around like the cursor does
+	var rotateX, rotateY float64
+	if input.Rotating {
+		rotateX, rotateY = o.RotateSpeed*input.LookX, o.RotateSpeed*input.LookY
+	}
+	rotateX += o.TurnSpeed * input.TurnX * dt
+	rotateY += o.TurnSpeed * input.TurnY * dt
+	o.goal.yaw -= rotateX
+	o.goal.pitch = clampPitch(o.goal.pitch + rotateY)
+
+	// Move the target against the drag so the model follows the cursor, and along the moving
+	// stick so the camera follows it
+	var panX, panY float64
+	if input.Panning {
+		panX, panY = -o.PanSpeed*input.LookX, o.PanSpeed*input.LookY
+	}
+	panX += o.StickPanSpeed * input.MoveX * dt
+	if panX != 0 || panY != 0 {
+		direction := fromAngles(o.goal.yaw, o.goal.pitch)
+		right := direction.Cross(mgl32.Vec3{0, 1, 0}).Normalize()
+		up := right.Cross(direction)
+		distance := float32(o.goal.distance)
+		o.goal.target = o.goal.target.
+			Add(right.Mul(float32(panX) * distance)).
+			Add(up.Mul(float32(panY) * distance))
+	}
+
+	// Pushing the moving stick forward dollies in like the scroll wheel
+	if zoom := input.zoom(dt) + input.MoveY*ZoomAxisRate*dt; zoom != 0 {
+		o.goal.distance *= math.Pow(1-o.DollySpeed, zoom)
+		o.goal.distance = math.Max(o.MinDistance, math.Min(o.MaxDistance, o.goal.distance))
+	}
+
+	// Exponential easing does not depend on how dt is sliced
+	t := 1.0
+	if o.Damping > 0 {
+		t = 1 - math.Exp(-dt/o.Damping)
+	}
+	o.Target = o.Target.Add(o.goal.target.Sub(o.Target).Mul(float32(t)))
+	o.Distance += (o.goal.distance - o.Distance) * t
+	o.Yaw += (o.goal.yaw - o.Yaw) * t
+	o.Pitch += (o.goal.pitch - o.Pitch) * t
+}
+
+// Frame - Moves the goal so the box from min to max fills the view, keeping the direction
+func (o *Orbit) Frame(min, max mgl32.Vec3) {
+	center := min.Add(max).Mul(0.5)
+	radius := float64(max.Sub(min).Len()) / 2
+
+	// The bounding sphere touches the top and bottom of the view; the horizontal field of
+	// view is at least as wide for any landscape target
+	halfFieldOfView := float64(mgl32.DegToRad(o.FieldOfView)) / 2
+	o.goal.target = center
+	o.goal.distance = math.Max(o.MinDistance, math.Min(o.MaxDistance, radius/math.Sin(halfFieldOfView)))
+}
+
+// Direction - The unit vector from the eye to the target
+func (o *Orbit) Direction() mgl32.Vec3 {
+	return fromAngles(o.Yaw, o.Pitch)
+}
+
+// Eye - Where the camera is
+func (o *Orbit) Eye() mgl32.Vec3 {
+	return eye(o.pose())
+}
+
+func eye(pose orbitPose) mgl32.Vec3 {
+	return pose.target.Sub(fromAngles(pose.yaw, pose.pitch).Mul(float32(pose.distance)))
+}
+
+// Pose - Where the camera is and where it looks
+func (o *Orbit) Pose() (mgl32.Vec3, mgl32.Vec3) {
+	return o.Eye(), o.Direction()
+}
+
+// SetPose - Places the eye at position looking along direction, orbiting the point the current
+// distance ahead
+func (o *Orbit) SetPose(position, direction mgl32.Vec3) {
+	direction = direction.Normalize()
+	o.Yaw, o.Pitch = angles(direction)
+	o.Pitch = clampPitch(o.Pitch)
+	o.Target = position.Add(direction.Mul(float32(o.Distance)))
+	o.Reset()
+}
+
+// View - The world to camera transform
+func (o *Orbit) View() mgl32.Mat4 {
+	return o.InterpolatedView(1)
+}
+
+// InterpolatedView - The view alpha of the way from the pose before the last update to the
+// current one
+func (o *Orbit) InterpolatedView(alpha float64) mgl32.Mat4 {
+	current := o.pose()
+	a := float32(alpha)
+	pose := orbitPose{
+		target:   o.previous.target.Add(current.target.Sub(o.previous.target).Mul(a)),
+		distance: o.previous.distance + (current.distance-o.previous.distance)*alpha,
+		yaw:      o.previous.yaw + (current.yaw-o.previous.yaw)*alpha,
+		pitch:    o.previous.pitch + (current.pitch-o.previous.pitch)*alpha,
+	}
+	return mgl32.LookAtV(eye(pose), pose.target, mgl32.Vec3{0, 1, 0})
+}
+
+// Projection - The perspective projection for a target of the given aspect ratio
+func (o *Orbit) Projection(aspect float32) mgl32.Mat4 {
+	return mgl32.Perspective(mgl32.DegToRad(o.FieldOfView), aspect, o.Near, o.Far)
+}
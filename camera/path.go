@@ -0,0 +1,302 @@
+package camera
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Keyframe - A camera pose a path passes
+type Keyframe struct {
+	Position mgl32.Vec3
+
+	// Orientation - The rotation from looking along +Z with +Y up to the view, like
+	// Camera.Orientation
+	Orientation mgl32.Quat
+}
+
+// NewKeyframe - The pose of a camera at position looking along direction, upright like Camera
+func NewKeyframe(position, direction mgl32.Vec3) Keyframe {
+	horizontal, vertical := angles(direction)
+	return Keyframe{Position: position, Orientation: yawPitch(horizontal, clampPitch(vertical))}
+}
+
+// Spline - How a path curves between its keyframes
+type Spline int
+
+const (
+	// CatmullRom - Through every keyframe, heading from the previous one towards the next
+	CatmullRom Spline = iota
+	// Bezier - One Bezier curve with the keyframes as control points: through the first and the
+	// last keyframe only, pulled towards the others, and smoother than CatmullRom
+	Bezier
+)
+
+var splineNames = map[Spline]string{CatmullRom: "catmull-rom", Bezier: "bezier"}
+
+func (s Spline) String() string {
+	return splineNames[s]
+}
+
+// ParseSpline - Reads the name of a spline, catmull-rom or bezier
+func ParseSpline(name string) (Spline, error) {
+	for s, sName := range splineNames {
+		if sName == name {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown spline %q, expected catmull-rom or bezier", name)
+}
+
+// MarshalJSON - Writes the spline by name
+func (s Spline) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON - Reads the spline by name
+func (s *Spline) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	parsed, err := ParseSpline(name)
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// Path - A camera flight through keyframes. The position follows the spline and the orientation
+// turns from keyframe to keyframe by slerp. It is timed by arc length, so the camera moves at
+// Speed however far apart the keyframes are.
+type Path struct {
+	Keyframes []Keyframe
+	Spline    Spline
+
+	// Speed - Units per second
+	Speed float64
+
+	// TurnWeight - Units of length a radian of turning adds to the path. At zero only moving
+	// takes time, and keyframes recorded in one place are turned between at once.
+	TurnWeight float64
+
+	// lengths[i] is the length of the path up to the parameter i/(len(lengths)-1), measured for
+	// the keyframes, spline and turn weight recorded next to it. The fields are exported, so
+	// anything can change between measurements.
+	lengths           []float64
+	measuredKeyframes []Keyframe
+	measuredSpline    Spline
+	measuredWeight    float64
+}
+
+// samplesPerKeyframe - Pieces per keyframe the arc length is measured in
+const samplesPerKeyframe = 64
+
+// NewPath - An empty path at 2 units per second
+func NewPath(spline Spline) *Path {
+	return &Path{Spline: spline, Speed: 2}
+}
+
+// Add - Appends a keyframe
+func (p *Path) Add(keyframe Keyframe) {
+	p.Keyframes = append(p.Keyframes, keyframe)
+}
+
+// Clear - Removes every keyframe
+func (p *Path) Clear() {
+	p.Keyframes = nil
+}
+
+// measure - Builds the arc length table if the path changed since it was last measured
+func (p *Path) measure() {
+	if len(p.Keyframes) < 2 {
+		p.lengths = nil
+		return
+	}
+	if p.lengths != nil && p.measuredSpline == p.Spline && p.measuredWeight == p.TurnWeight && sameKeyframes(p.measuredKeyframes, p.Keyframes) {
+		return
+	}
+	p.measuredKeyframes = append(p.measuredKeyframes[:0], p.Keyframes...)
+	p.measuredSpline, p.measuredWeight = p.Spline, p.TurnWeight
+	samples := samplesPerKeyframe * (len(p.Keyframes) - 1)
+	p.lengths = make([]float64, samples+1)
+	previous, previousOrientation := p.point(0), p.orientation(0)
+	for i := 1; i <= samples; i++ {
+		u := float64(i) / float64(samples)
+		point, orientation := p.point(u), p.orientation(u)
+		turned := 2 * math.Acos(math.Min(1, math.Abs(float64(orientation.Dot(previousOrientation)))))
+		p.lengths[i] = p.lengths[i-1] + float64(point.Sub(previous).Len()) + p.TurnWeight*turned
+		previous, previousOrientation = point, orientation
+	}
+}
+
+func sameKeyframes(a, b []Keyframe) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Length - The arc length of the path, plus TurnWeight for every radian it turns
+func (p *Path) Length() float64 {
+	p.measure()
+	if len(p.lengths) == 0 {
+		return 0
+	}
+	return p.lengths[len(p.lengths)-1]
+}
+
+// Duration - Seconds the camera takes from the first keyframe to the last
+func (p *Path) Duration() float64 {
+	if p.Speed <= 0 {
+		return 0
+	}
+	return p.Length() / p.Speed
+}
+
+// At - The pose t seconds into the path, holding the first and last keyframes before and after it
+func (p *Path) At(t float64) Keyframe {
+	switch len(p.Keyframes) {
+	case 0:
+		return Keyframe{Orientation: mgl32.QuatIdent()}
+	case 1:
+		return p.Keyframes[0]
+	}
+
+	u := 0.0
+	if duration := p.Duration(); duration > 0 {
+		u = p.parameter(math.Max(0, math.Min(1, t/duration)))
+	}
+	return Keyframe{Position: p.point(u), Orientation: p.orientation(u)}
+}
+
+// parameter - The spline parameter the given fraction of the arc length is reached at
+func (p *Path) parameter(fraction float64) float64 {
+	length := p.Length()
+	if length == 0 {
+		return fraction
+	}
+	target := fraction * length
+	i := sort.SearchFloat64s(p.lengths, target)
+	if i == 0 {
+		return 0
+	}
+	if i >= len(p.lengths) {
+		return 1
+	}
+	// Linear between the two samples around the target
+	before, after := p.lengths[i-1], p.lengths[i]
+	within := 0.0
+	if after > before {
+		within = (target - before) / (after - before)
+	}
+	return (float64(i-1) + within) / float64(len(p.lengths)-1)
+}
+
+// segment - The keyframe a parameter from 0 to 1 starts from, and how far it is towards the next
+func (p *Path) segment(u float64) (int, float32) {
+	scaled := u * float64(len(p.Keyframes)-1)
+	i := int(math.Min(math.Floor(scaled), float64(len(p.Keyframes)-2)))
+	return i, float32(scaled - float64(i))
+}
+
+// point - The position at a parameter from 0 to 1
+func (p *Path) point(u float64) mgl32.Vec3 {
+	if p.Spline == Bezier {
+		points := make([]mgl32.Vec3, len(p.Keyframes))
+		for i, keyframe := range p.Keyframes {
+			points[i] = keyframe.Position
+		}
+		return mgl32.BezierCurve3D(float32(u), points)
+	}
+
+	// The ends are repeated, so the path starts and stops heading straight at its neighbour
+	i, t := p.segment(u)
+	at := func(j int) mgl32.Vec3 {
+		j = int(math.Max(0, math.Min(float64(j), float64(len(p.Keyframes)-1))))
+		return p.Keyframes[j].Position
+	}
+	p0, p1, p2, p3 := at(i-1), at(i), at(i+1), at(i+2)
+	t2, t3 := t*t, t*t*t
+	return p0.Mul(-t3 + 2*t2 - t).
+		Add(p1.Mul(3*t3 - 5*t2 + 2)).
+		Add(p2.Mul(-3*t3 + 4*t2 + t)).
+		Add(p3.Mul(t3 - t2)).
+		Mul(0.5)
+}
+
+// orientation - The orientation at a parameter from 0 to 1
+func (p *Path) orientation(u float64) mgl32.Quat {
+	i, t := p.segment(u)
+	return mgl32.QuatSlerp(p.Keyframes[i].Orientation, p.Keyframes[i+1].Orientation, t)
+}
+
+// pathFile - How a path is saved: positions as [x, y, z] and orientations as [w, x, y, z]
+type pathFile struct {
+	Spline     Spline         `json:"spline"`
+	Speed      float64        `json:"speed"`
+	TurnWeight float64        `json:"turnWeight,omitempty"`
+	Keyframes  []pathKeyframe `json:"keyframes"`
+}
+
+type pathKeyframe struct {
+	Position    [3]float32 `json:"position"`
+	Orientation [4]float32 `json:"orientation"`
+}
+
+// Save - Writes the path to a JSON file
+func (p *Path) Save(file string) error {
+	out := pathFile{Spline: p.Spline, Speed: p.Speed, TurnWeight: p.TurnWeight}
+	out.Keyframes = make([]pathKeyframe, len(p.Keyframes))
+	for i, keyframe := range p.Keyframes {
+		q := keyframe.Orientation
+		out.Keyframes[i].Position = keyframe.Position
+		out.Keyframes[i].Orientation = [4]float32{q.W, q.V[0], q.V[1], q.V[2]}
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, append(data, '\n'), 0644)
+}
+
+// LoadPath - Reads a path Save wrote
+func LoadPath(file string) (*Path, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var in pathFile
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	if in.Speed <= 0 {
+		return nil, fmt.Errorf("%s: speed must be positive, got %g", file, in.Speed)
+	}
+
+	if in.TurnWeight < 0 {
+		return nil, fmt.Errorf("%s: turn weight must not be negative, got %g", file, in.TurnWeight)
+	}
+
+	p := &Path{Spline: in.Spline, Speed: in.Speed, TurnWeight: in.TurnWeight}
+	for i, keyframe := range in.Keyframes {
+		o := keyframe.Orientation
+		q := mgl32.Quat{W: o[0], V: mgl32.Vec3{o[1], o[2], o[3]}}
+		if q.Len() == 0 {
+			return nil, fmt.Errorf("%s: keyframe %d: orientation is zero", file, i)
+		}
+		p.Add(Keyframe{Position: keyframe.Position, Orientation: q.Normalize()})
+	}
+	return p, nil
+}
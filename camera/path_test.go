@@ -0,0 +1,117 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// testKeyframes - Unevenly spaced keyframes that turn, so arc length and parameter differ
+func testKeyframes() []Keyframe {
+	return []Keyframe{
+		NewKeyframe(mgl32.Vec3{0, 0, 0}, mgl32.Vec3{0, 0, 1}),
+		NewKeyframe(mgl32.Vec3{1, 0, 0.5}, mgl32.Vec3{1, 0, 1}),
+		NewKeyframe(mgl32.Vec3{6, 1, 2}, mgl32.Vec3{1, 0, 0}),
+		NewKeyframe(mgl32.Vec3{6, 2, 8}, mgl32.Vec3{0, -1, 1}),
+		NewKeyframe(mgl32.Vec3{7, 2, 8.5}, mgl32.Vec3{-1, 0, 0}),
+	}
+}
+
+func testPath(spline Spline) *Path {
+	p := NewPath(spline)
+	for _, keyframe := range testKeyframes() {
+		p.Add(keyframe)
+	}
+	return p
+}
+
+func TestCatmullRomThroughKeyframes(t *testing.T) {
+	p := testPath(CatmullRom)
+	last := float64(len(p.Keyframes) - 1)
+	for i, keyframe := range p.Keyframes {
+		if got := p.point(float64(i) / last); !near(got[:], keyframe.Position[:]) {
+			t.Errorf("keyframe %d: path is at %v, want %v", i, got, keyframe.Position)
+		}
+	}
+
+	// The first and last keyframes are where the path is held before and after it
+	for _, test := range []struct {
+		at   float64
+		want mgl32.Vec3
+	}{
+		{-1, p.Keyframes[0].Position},
+		{0, p.Keyframes[0].Position},
+		{p.Duration(), p.Keyframes[len(p.Keyframes)-1].Position},
+		{p.Duration() + 1, p.Keyframes[len(p.Keyframes)-1].Position},
+	} {
+		if got := p.At(test.at).Position; !near(got[:], test.want[:]) {
+			t.Errorf("at %vs the path is at %v, want %v", test.at, got, test.want)
+		}
+	}
+}
+
+func TestEqualTimeEqualLength(t *testing.T) {
+	const steps = 100
+	tests := []struct {
+		spline     Spline
+		turnWeight float64
+	}{
+		{CatmullRom, 0},
+		{Bezier, 0},
+		{CatmullRom, 0.5},
+	}
+	for _, test := range tests {
+		p := testPath(test.spline)
+		p.TurnWeight = test.turnWeight
+		step := p.Duration() / steps
+		want := p.Length() / steps
+
+		// Short steps are close enough to straight to measure by their chord, the table in between
+		// samples is linear
+		previous := p.At(0)
+		for i := 1; i <= steps; i++ {
+			next := p.At(float64(i) * step)
+			moved := float64(next.Position.Sub(previous.Position).Len())
+			turned := 2 * math.Acos(math.Min(1, math.Abs(float64(next.Orientation.Dot(previous.Orientation)))))
+			if got := moved + test.turnWeight*turned; math.Abs(got-want) > 0.05*want {
+				t.Errorf("%v with turn weight %v: step %d covers %v, want %v", test.spline, test.turnWeight, i, got, want)
+			}
+			previous = next
+		}
+	}
+}
+
+func TestMeasureChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(p *Path)
+	}{
+		{"spline", func(p *Path) { p.Spline = Bezier }},
+		{"turn weight", func(p *Path) { p.TurnWeight = 1 }},
+		{"keyframe moved", func(p *Path) { p.Keyframes[2].Position = mgl32.Vec3{20, 0, 0} }},
+		{"keyframe turned", func(p *Path) { p.Keyframes[1].Orientation = mgl32.QuatRotate(2, mgl32.Vec3{0, 1, 0}) }},
+		{"keyframe appended", func(p *Path) {
+			p.Keyframes = append(p.Keyframes, NewKeyframe(mgl32.Vec3{0, 0, 20}, mgl32.Vec3{0, 0, 1}))
+		}},
+		{"keyframes cut", func(p *Path) { p.Keyframes = p.Keyframes[:3] }},
+		{"one keyframe left", func(p *Path) { p.Keyframes = p.Keyframes[:1] }},
+		{"cleared and added", func(p *Path) {
+			p.Clear()
+			p.Add(NewKeyframe(mgl32.Vec3{}, mgl32.Vec3{0, 0, 1}))
+			p.Add(NewKeyframe(mgl32.Vec3{3, 4, 0}, mgl32.Vec3{0, 0, 1}))
+		}},
+	}
+	for _, test := range tests {
+		p := testPath(CatmullRom)
+		p.Length()
+		test.change(p)
+
+		// A path that never measured the old keyframes
+		fresh := &Path{Spline: p.Spline, Speed: p.Speed, TurnWeight: p.TurnWeight}
+		fresh.Keyframes = append(fresh.Keyframes, p.Keyframes...)
+		if got, want := p.Length(), fresh.Length(); got != want {
+			t.Errorf("%s: length is %v, want %v", test.name, got, want)
+		}
+	}
+}
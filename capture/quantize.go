@@ -0,0 +1,105 @@
+package capture
+
+import (
+	"image"
+	"image/color"
+	"sort"
+)
+
+// maxSamples - Most pixels a palette is computed from, larger images are sampled evenly
+const maxSamples = 1 << 16
+
+// MedianCut - A palette of at most colors colors for img. The pixels are put in a box that is
+// split at the median of its widest channel until there are enough boxes, and every box
+// becomes the average of its pixels, so common colors get finer steps than rare ones.
+func MedianCut(img *image.RGBA, colors int) color.Palette {
+	bounds := img.Bounds()
+	pixels := bounds.Dx() * bounds.Dy()
+	step := 1
+	if pixels > maxSamples {
+		step = pixels / maxSamples
+	}
+
+	samples := make([][3]uint8, 0, pixels/step+1)
+	for i := 0; i < pixels; i += step {
+		x, y := bounds.Min.X+i%bounds.Dx(), bounds.Min.Y+i/bounds.Dx()
+		offset := img.PixOffset(x, y)
+		samples = append(samples, [3]uint8{img.Pix[offset], img.Pix[offset+1], img.Pix[offset+2]})
+	}
+	if len(samples) == 0 {
+		return color.Palette{color.Black}
+	}
+
+	boxes := []box{newBox(samples)}
+	for len(boxes) < colors {
+		// Split the box spanning the widest range; boxes of one color cannot be split
+		widest, widestRange := -1, 0
+		for i, b := range boxes {
+			if _, r := b.widestChannel(); r > widestRange && len(b.pixels) > 1 {
+				widest, widestRange = i, r
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		a, b := boxes[widest].split()
+		boxes[widest] = a
+		boxes = append(boxes, b)
+	}
+
+	palette := make(color.Palette, len(boxes))
+	for i, b := range boxes {
+		palette[i] = b.average()
+	}
+	return palette
+}
+
+// box - Pixels of similar color
+type box struct {
+	pixels   [][3]uint8
+	min, max [3]uint8
+}
+
+func newBox(pixels [][3]uint8) box {
+	b := box{pixels: pixels, min: [3]uint8{255, 255, 255}}
+	for _, p := range pixels {
+		for c := 0; c < 3; c++ {
+			if p[c] < b.min[c] {
+				b.min[c] = p[c]
+			}
+			if p[c] > b.max[c] {
+				b.max[c] = p[c]
+			}
+		}
+	}
+	return b
+}
+
+func (b box) widestChannel() (int, int) {
+	channel, width := 0, -1
+	for c := 0; c < 3; c++ {
+		if w := int(b.max[c]) - int(b.min[c]); w > width {
+			channel, width = c, w
+		}
+	}
+	return channel, width
+}
+
+// split - The pixels below and above the median of the widest channel
+func (b box) split() (box, box) {
+	channel, _ := b.widestChannel()
+	sort.Slice(b.pixels, func(i, j int) bool { return b.pixels[i][channel] < b.pixels[j][channel] })
+	median := len(b.pixels) / 2
+	return newBox(b.pixels[:median]), newBox(b.pixels[median:])
+}
+
+func (b box) average() color.Color {
+	var sum [3]int
+	for _, p := range b.pixels {
+		for c := 0; c < 3; c++ {
+			sum[c] += int(p[c])
+		}
+	}
+	n := len(b.pixels)
+	return color.RGBA{uint8(sum[0] / n), uint8(sum[1] / n), uint8(sum[2] / n), 0xff}
+}
@@ -0,0 +1,157 @@
+// Package capture writes rendered frames to disk: single screenshots, numbered PNG sequences and
+// animated GIFs.
+package capture
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// Recorder - Writes the frames it is given to an animated GIF when its file ends in .gif, or to a
+// numbered PNG sequence when it ends in .png: shot.png becomes shot_0000.png, shot_0001.png, ...
+//
+// GIF frames are quantized to a palette of their own with MedianCut as they come in, on every
+// core, and written when the recorder is closed, so only their palette indices are kept in
+// memory. PNG frames are written right away.
+type Recorder struct {
+	// Dither - Spreads the error of every GIF pixel over its neighbours with Floyd-Steinberg,
+	// trading banding in gradients for noise
+	Dither bool
+
+	// Colors - Palette size of every GIF frame, at most 256
+	Colors int
+
+	file  string
+	isGIF bool
+	delay int
+
+	size   image.Point
+	count  int
+	frames []*image.Paletted
+
+	// Quantizing runs on workers, at most one frame per core at a time
+	workers sync.WaitGroup
+	busy    chan struct{}
+	mutex   sync.Mutex
+}
+
+// NewRecorder - A recorder of frames timeStep seconds apart to file. GIFs count time in
+// hundredths of a second and browsers slow down anything faster than 50 frames per second, so
+// GIF frames last at least two hundredths.
+func NewRecorder(file string, timeStep float64) (*Recorder, error) {
+	r := &Recorder{Dither: true, Colors: 256, file: file, busy: make(chan struct{}, runtime.NumCPU())}
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".gif":
+		r.isGIF = true
+		r.delay = int(math.Max(2, math.Round(timeStep*100)))
+	case ".png":
+	default:
+		return nil, fmt.Errorf("%s: captures are written as .gif or .png", file)
+	}
+	return r, nil
+}
+
+// Frames - How many frames were added
+func (r *Recorder) Frames() int {
+	return r.count
+}
+
+// Add - Appends a frame. Every frame has to be the size of the first. It is called from one
+// goroutine only and blocks while every core is quantizing.
+func (r *Recorder) Add(img *image.RGBA) error {
+	size := img.Bounds().Size()
+	if r.count == 0 {
+		r.size = size
+	} else if size != r.size {
+		return fmt.Errorf("frame %d is %v, the capture started at %v", r.count, size, r.size)
+	}
+
+	i := r.count
+	r.count++
+	if !r.isGIF {
+		return helpers.SavePNG(r.sequenceFile(i), img)
+	}
+
+	r.mutex.Lock()
+	r.frames = append(r.frames, nil)
+	r.mutex.Unlock()
+
+	r.busy <- struct{}{}
+	r.workers.Add(1)
+	go func() {
+		defer r.workers.Done()
+		paletted := r.quantize(img)
+		<-r.busy
+
+		r.mutex.Lock()
+		r.frames[i] = paletted
+		r.mutex.Unlock()
+	}()
+	return nil
+}
+
+// sequenceFile - The file of frame i of a PNG sequence
+func (r *Recorder) sequenceFile(i int) string {
+	ext := filepath.Ext(r.file)
+	return fmt.Sprintf("%s_%04d%s", strings.TrimSuffix(r.file, ext), i, ext)
+}
+
+func (r *Recorder) quantize(img *image.RGBA) *image.Paletted {
+	colors := r.Colors
+	if colors < 2 || colors > 256 {
+		colors = 256
+	}
+	paletted := image.NewPaletted(img.Bounds(), MedianCut(img, colors))
+	if r.Dither {
+		draw.FloydSteinberg.Draw(paletted, img.Bounds(), img, img.Bounds().Min)
+	} else {
+		draw.Draw(paletted, img.Bounds(), img, img.Bounds().Min, draw.Src)
+	}
+	return paletted
+}
+
+// Close - Waits for the frames still being quantized and writes the GIF. It returns where the
+// frames went.
+func (r *Recorder) Close() (string, error) {
+	r.workers.Wait()
+	if r.count == 0 {
+		return "", fmt.Errorf("no frames captured")
+	}
+	if !r.isGIF {
+		return fmt.Sprintf("%s to %s", r.sequenceFile(0), filepath.Base(r.sequenceFile(r.count-1))), nil
+	}
+
+	out := &gif.GIF{Image: r.frames, Delay: make([]int, len(r.frames))}
+	for i := range out.Delay {
+		out.Delay[i] = r.delay
+	}
+	f, err := os.Create(r.file)
+	if err != nil {
+		return "", err
+	}
+	if err := gif.EncodeAll(f, out); err != nil {
+		f.Close()
+		return "", fmt.Errorf("failed to encode %q: %v", r.file, err)
+	}
+	return r.file, f.Close()
+}
+
+// Screenshot - Writes img to a PNG in dir named after the time it was taken
+func Screenshot(dir string, img image.Image) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	file := filepath.Join(dir, "screenshot_"+time.Now().Format("20060102_150405.000")+".png")
+	return file, helpers.SavePNG(file, img)
+}
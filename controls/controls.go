@@ -0,0 +1,193 @@
+// Package controls maps named actions to the keys, mouse buttons and scroll axes of a window and
+// the buttons of a gamepad, so what triggers an action comes from configuration instead of being
+// tested inline. All input is read through a Device, which can record it and replay it.
+package controls
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Mode - When an action counts as active
+type Mode int
+
+const (
+	// Hold - Active for as long as one of its bindings is down, like moving
+	Hold Mode = iota
+	// Press - Active only in the poll in which it went down, like toggling
+	Press
+)
+
+// Actions - The actions there are and the mode of each
+type Actions map[string]Mode
+
+// names - The action names in order, for error messages
+func (a Actions) names() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Bindings - What triggers each action, as the bindings ParseBinding reads. Any one binding
+// of an action triggers it.
+type Bindings map[string][]string
+
+// Check - Reports the first action that is unknown or has a binding that does not parse
+func (b Bindings) Check(actions Actions) error {
+	_, err := b.parse(actions)
+	return err
+}
+
+func (b Bindings) parse(actions Actions) (map[string][]Binding, error) {
+	names := make([]string, 0, len(b))
+	for name := range b {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parsed := make(map[string][]Binding, len(b))
+	for _, name := range names {
+		if _, ok := actions[name]; !ok {
+			return nil, fmt.Errorf("%s: unknown action, expected one of %s", name, strings.Join(actions.names(), ", "))
+		}
+		for _, s := range b[name] {
+			binding, err := ParseBinding(s)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", name, err)
+			}
+			parsed[name] = append(parsed[name], binding)
+		}
+	}
+	return parsed, nil
+}
+
+// Map - The state of a device's actions, sampled once per update with Poll after the device was
+// polled. A binding whose chord is part of a longer chord that is down does not trigger, so
+// Ctrl+W does not also move forward when W does.
+type Map struct {
+	device  *Device
+	actions map[string]*action
+	order   []string
+}
+
+type action struct {
+	mode     Mode
+	bindings []Binding
+
+	down, wasDown bool
+	value         float64
+}
+
+// NewMap - Returns the actions of device triggered by bindings. Actions without bindings are
+// never active.
+func NewMap(device *Device, actions Actions, bindings Bindings) (*Map, error) {
+	parsed, err := bindings.parse(actions)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Map{device: device, actions: make(map[string]*action, len(actions)), order: actions.names()}
+	for name, mode := range actions {
+		m.actions[name] = &action{mode: mode, bindings: parsed[name]}
+	}
+	return m, nil
+}
+
+// Poll - Samples every action from the device's last poll
+func (m *Map) Poll() {
+	scrollX, scrollY := m.device.Scroll()
+
+	// Every binding whose chord is held, before longer chords suppress shorter ones
+	held := make(map[string][]Binding)
+	var all []Binding
+	for _, name := range m.order {
+		for _, binding := range m.actions[name].bindings {
+			if binding.held(m.device) {
+				held[name] = append(held[name], binding)
+				all = append(all, binding)
+			}
+		}
+	}
+
+	for _, name := range m.order {
+		a := m.actions[name]
+		a.wasDown, a.down, a.value = a.down, false, 0
+		for _, binding := range held[name] {
+			if suppressed(binding, all) {
+				continue
+			}
+			value := 1.0
+			if binding.axis != noAxis {
+				value = binding.axis.value(scrollX, scrollY)
+			}
+			a.down = a.down || value != 0
+			a.value += value
+		}
+	}
+}
+
+// held - Whether every control of the chord is down
+func (b Binding) held(device *Device) bool {
+	for _, c := range b.chord {
+		if !c.down(device) {
+			return false
+		}
+	}
+	return len(b.chord) > 0 || b.axis != noAxis
+}
+
+// suppressed - Whether a longer held chord contains binding's
+func suppressed(binding Binding, held []Binding) bool {
+	for _, other := range held {
+		if len(other.chord) > len(binding.chord) && other.contains(binding) {
+			return true
+		}
+	}
+	return false
+}
+
+// Active - Whether the action is down for a Hold action, or went down in the last poll for a
+// Press action
+func (m *Map) Active(name string) bool {
+	a := m.lookup(name)
+	if a.mode == Press {
+		return a.down && !a.wasDown
+	}
+	return a.down
+}
+
+// Down - Whether any binding of the action was down at the last poll
+func (m *Map) Down(name string) bool {
+	return m.lookup(name).down
+}
+
+// Pressed - Whether the action went down in the last poll
+func (m *Map) Pressed(name string) bool {
+	a := m.lookup(name)
+	return a.down && !a.wasDown
+}
+
+// Released - Whether the action went up in the last poll
+func (m *Map) Released(name string) bool {
+	a := m.lookup(name)
+	return !a.down && a.wasDown
+}
+
+// Value - The scroll notches of the action's axis bindings in the last poll, plus one for every
+// other binding that is down
+func (m *Map) Value(name string) float64 {
+	return m.lookup(name).value
+}
+
+// lookup - Panics on names that are not actions, which are mistakes in the code, not the config
+func (m *Map) lookup(name string) *action {
+	a, ok := m.actions[name]
+	if !ok {
+		panic(fmt.Sprintf("controls: unknown action %q", name))
+	}
+	return a
+}
@@ -0,0 +1,143 @@
+package controls
+
+import (
+	"testing"
+)
+
+// poll - What is held and scrolled in one update, and the actions expected to be down, pressed,
+// released and active afterwards. Actions not listed are expected not to be.
+type poll struct {
+	held    []Key
+	scrollY float64
+
+	down, pressed, released, active []string
+	values                          map[string]float64
+}
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		actions  Actions
+		bindings Bindings
+		polls    []poll
+	}{
+		{
+			"hold and press",
+			Actions{"move": Hold, "toggle": Press},
+			Bindings{"move": {"W"}, "toggle": {"T"}},
+			[]poll{
+				{held: nil},
+				{held: []Key{letter('W'), letter('T')},
+					down: []string{"move", "toggle"}, pressed: []string{"move", "toggle"}, active: []string{"move", "toggle"}},
+				// Held on, a Press action is no longer active
+				{held: []Key{letter('W'), letter('T')},
+					down: []string{"move", "toggle"}, active: []string{"move"}},
+				{held: []Key{letter('W')},
+					down: []string{"move"}, released: []string{"toggle"}, active: []string{"move"}},
+				{held: nil, released: []string{"move"}},
+				{held: nil},
+			},
+		},
+		{
+			"chord suppresses the shorter binding",
+			Actions{"forward": Hold, "close": Press},
+			Bindings{"forward": {"W"}, "close": {"Ctrl+W"}},
+			[]poll{
+				{held: []Key{letter('W')}, down: []string{"forward"}, pressed: []string{"forward"}, active: []string{"forward"}},
+				// Adding Ctrl releases forward and presses close
+				{held: []Key{KeyLeftControl, letter('W')},
+					down: []string{"close"}, pressed: []string{"close"}, released: []string{"forward"}, active: []string{"close"}},
+				{held: []Key{KeyLeftControl, letter('W')}, down: []string{"close"}},
+				// Letting go of Ctrl with W still held presses forward again
+				{held: []Key{letter('W')},
+					down: []string{"forward"}, pressed: []string{"forward"}, released: []string{"close"}, active: []string{"forward"}},
+				// Ctrl on its own is part of no chord that is held
+				{held: []Key{KeyRightControl}, released: []string{"forward"}},
+				// Either Ctrl key makes the chord
+				{held: []Key{KeyRightControl, letter('W')}, down: []string{"close"}, pressed: []string{"close"}, active: []string{"close"}},
+			},
+		},
+		{
+			"another binding of a suppressed action still triggers it",
+			Actions{"forward": Hold, "close": Press},
+			Bindings{"forward": {"W", "Up"}, "close": {"Ctrl+W"}},
+			[]poll{
+				{held: []Key{KeyLeftControl, letter('W'), KeyUp},
+					down: []string{"close", "forward"}, pressed: []string{"close", "forward"}, active: []string{"close", "forward"},
+					values: map[string]float64{"forward": 1, "close": 1}},
+				{held: []Key{letter('W'), KeyUp},
+					down: []string{"forward"}, released: []string{"close"}, active: []string{"forward"},
+					values: map[string]float64{"forward": 2}},
+			},
+		},
+		{
+			"scroll axes",
+			Actions{"zoom": Hold, "fine": Hold, "in": Press},
+			Bindings{"zoom": {"ScrollY"}, "fine": {"Shift+ScrollY"}, "in": {"ScrollUp"}},
+			[]poll{
+				{scrollY: 2, down: []string{"zoom", "in"}, pressed: []string{"zoom", "in"}, active: []string{"zoom", "in"},
+					values: map[string]float64{"zoom": 2, "in": 2}},
+				{scrollY: -1, down: []string{"zoom"}, released: []string{"in"}, active: []string{"zoom"},
+					values: map[string]float64{"zoom": -1}},
+				// A wheel that stops releases the axis
+				{released: []string{"zoom"}},
+				// Holding Shift moves the scrolling to fine, every axis without a chord is part of it
+				{held: []Key{KeyLeftShift}, scrollY: 3, down: []string{"fine"}, pressed: []string{"fine"}, active: []string{"fine"},
+					values: map[string]float64{"fine": 3}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		device := NewDevice(nil)
+		m, err := NewMap(device, test.actions, test.bindings)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		for i, p := range test.polls {
+			// Without a window the device keeps whatever frame it was given
+			device.frame = newFrame()
+			for _, key := range p.held {
+				device.frame.keys[key] = true
+			}
+			device.frame.scrollY = p.scrollY
+			device.Poll()
+			m.Poll()
+
+			for _, name := range test.actions.names() {
+				checks := []struct {
+					state string
+					got   bool
+					want  []string
+				}{
+					{"down", m.Down(name), p.down},
+					{"pressed", m.Pressed(name), p.pressed},
+					{"released", m.Released(name), p.released},
+					{"active", m.Active(name), p.active},
+				}
+				for _, check := range checks {
+					if want := contains(check.want, name); check.got != want {
+						t.Errorf("%s: poll %d: %s %s is %v, want %v", test.name, i, name, check.state, check.got, want)
+					}
+				}
+				if got, want := m.Value(name), p.values[name]; p.values != nil && got != want {
+					t.Errorf("%s: poll %d: %s value is %v, want %v", test.name, i, name, got, want)
+				}
+			}
+		}
+	}
+}
+
+// letter - The key of an upper case letter
+func letter(c rune) Key {
+	return KeyA + Key(c-'A')
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
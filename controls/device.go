@@ -0,0 +1,170 @@
+package controls
+
+import (
+	"log"
+)
+
+// frame - Everything input reads in one update
+type frame struct {
+	keys    map[Key]bool
+	buttons map[MouseButton]bool
+
+	cursorX, cursorY float64
+
+	// scroll is the wheel movement since the previous update
+	scrollX, scrollY float64
+
+	padAxes    [gamepadAxes]float64
+	padButtons [gamepadButtons]bool
+}
+
+func newFrame() frame {
+	return frame{keys: make(map[Key]bool), buttons: make(map[MouseButton]bool)}
+}
+
+// copy - A frame that does not share the held keys and buttons with f
+func (f frame) copy() frame {
+	c := f
+	c.keys, c.buttons = make(map[Key]bool, len(f.keys)), make(map[MouseButton]bool, len(f.buttons))
+	for key := range f.keys {
+		c.keys[key] = true
+	}
+	for button := range f.buttons {
+		c.buttons[button] = true
+	}
+	return c
+}
+
+// Device - The keyboard, mouse and first gamepad of a window, sampled once per update. Everything
+// that reads input reads the sample, so it can be recorded and replayed: while a replay runs the
+// samples come from the recording instead of the window. Without a window and a replay nothing is
+// ever held and nothing moves.
+type Device struct {
+	window  Window
+	gamepad *Gamepad
+
+	// Scrolling collected by the callback since the last poll
+	pendingX, pendingY float64
+
+	frame frame
+	tick  int
+
+	recording *recording
+	replay    *replay
+}
+
+// NewDevice - The input of window, which may be nil. When window implements Joysticks the device
+// reads the first gamepad as well.
+func NewDevice(window Window) *Device {
+	d := &Device{window: window, frame: newFrame()}
+	if joysticks, ok := window.(Joysticks); ok {
+		d.gamepad = NewGamepad(joysticks)
+	}
+	return d
+}
+
+// Attach - Starts collecting the window's scrolling and following gamepad connects
+func (d *Device) Attach() {
+	if d.window == nil {
+		return
+	}
+	d.window.SetScrollCallback(func(xoff, yoff float64) {
+		d.pendingX += xoff
+		d.pendingY += yoff
+	})
+	d.gamepad.Attach()
+}
+
+// Detach - Removes the callbacks Attach installed
+func (d *Device) Detach() {
+	if d.window == nil {
+		return
+	}
+	d.window.SetScrollCallback(nil)
+	d.gamepad.Detach()
+}
+
+// ConfigureGamepad - Sets the deadzone and response curve of the gamepad, see Gamepad
+func (d *Device) ConfigureGamepad(deadzone, curve float64) {
+	if d.gamepad != nil {
+		d.gamepad.Deadzone, d.gamepad.Curve = deadzone, curve
+	}
+}
+
+// Poll - Takes the next sample, from the replay while there is one and from the window otherwise
+func (d *Device) Poll() {
+	d.tick++
+
+	replayed := d.replay != nil && d.replay.next(&d.frame, d.tick)
+	if d.replay != nil && !replayed {
+		log.Printf("replay finished after %d updates", d.tick-1-d.replay.start)
+		d.replay = nil
+		// Whatever was scrolled during the replay does not belong to the live input
+		d.pendingX, d.pendingY = 0, 0
+	}
+	if !replayed && d.window != nil {
+		d.sample()
+	}
+
+	if d.recording != nil {
+		d.recording.write(d.tick, d.frame)
+	}
+}
+
+// sample - Reads the window and the gamepad
+func (d *Device) sample() {
+	for key := range keyNames {
+		if d.window.Key(key) {
+			d.frame.keys[key] = true
+		} else {
+			delete(d.frame.keys, key)
+		}
+	}
+	for button := range buttonNames {
+		if d.window.MouseButton(button) {
+			d.frame.buttons[button] = true
+		} else {
+			delete(d.frame.buttons, button)
+		}
+	}
+	d.frame.cursorX, d.frame.cursorY = d.window.Cursor()
+	d.frame.scrollX, d.frame.scrollY, d.pendingX, d.pendingY = d.pendingX, d.pendingY, 0, 0
+
+	d.gamepad.Poll()
+	for axis := range d.frame.padAxes {
+		d.frame.padAxes[axis] = d.gamepad.Axis(GamepadAxis(axis))
+	}
+	for button := range d.frame.padButtons {
+		d.frame.padButtons[button] = d.gamepad.Button(GamepadButton(button))
+	}
+}
+
+// Key - Whether key was down at the last poll
+func (d *Device) Key(key Key) bool {
+	return d.frame.keys[key]
+}
+
+// MouseButton - Whether button was down at the last poll
+func (d *Device) MouseButton(button MouseButton) bool {
+	return d.frame.buttons[button]
+}
+
+// Cursor - Where the cursor was at the last poll, in screen coordinates
+func (d *Device) Cursor() (float64, float64) {
+	return d.frame.cursorX, d.frame.cursorY
+}
+
+// Scroll - How far the wheel moved between the last two polls
+func (d *Device) Scroll() (float64, float64) {
+	return d.frame.scrollX, d.frame.scrollY
+}
+
+// PadAxis - A gamepad axis at the last poll, past the deadzone and curve
+func (d *Device) PadAxis(axis GamepadAxis) float64 {
+	return d.frame.padAxes[axis]
+}
+
+// PadButton - Whether a gamepad button was down at the last poll
+func (d *Device) PadButton(button GamepadButton) bool {
+	return d.frame.padButtons[button]
+}
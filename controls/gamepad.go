@@ -0,0 +1,158 @@
+package controls
+
+import (
+	"log"
+	"math"
+)
+
+// Gamepad - The first connected joystick, read through the mapping of its name as the standard
+// layout. Joysticks are picked up and dropped as they are connected and disconnected. A nil
+// Gamepad never has a joystick.
+type Gamepad struct {
+	// Deadzone - Fraction of a stick's or trigger's travel around rest that reads as zero.
+	// Sticks use a radial deadzone, so pushing along one axis does not snap the other to zero.
+	Deadzone float64
+
+	// Curve - Exponent of the response past the deadzone: 1 is linear, higher values give finer
+	// control near the middle and keep full deflection
+	Curve float64
+
+	joysticks Joysticks
+	joystick  int
+	name      string
+	mapping   *Mapping
+	present   bool
+
+	// rescan is set by connects and disconnects to look for a joystick at the next poll
+	rescan bool
+
+	axes    [gamepadAxes]float64
+	buttons [gamepadButtons]bool
+}
+
+// NewGamepad - A gamepad with a 15% deadzone and a quadratic response, looking for a joystick
+// among joysticks at the first poll
+func NewGamepad(joysticks Joysticks) *Gamepad {
+	return &Gamepad{Deadzone: 0.15, Curve: 2, joysticks: joysticks, rescan: true}
+}
+
+// Attach - Starts following connects and disconnects. GLFW has one joystick callback for the
+// whole process, so only one gamepad can be attached at a time.
+func (g *Gamepad) Attach() {
+	if g == nil {
+		return
+	}
+	g.joysticks.SetJoystickCallback(func() {
+		g.rescan = true
+	})
+}
+
+// Detach - Stops following connects and disconnects
+func (g *Gamepad) Detach() {
+	if g != nil {
+		g.joysticks.SetJoystickCallback(nil)
+	}
+}
+
+// scan - Picks the first joystick that is present
+func (g *Gamepad) scan() {
+	g.rescan = false
+	wasPresent, previous := g.present, g.name
+
+	g.present = false
+	for joy := 0; joy < MaxJoysticks; joy++ {
+		if g.joysticks.JoystickPresent(joy) {
+			g.joystick, g.present = joy, true
+			g.name = g.joysticks.JoystickName(joy)
+			break
+		}
+	}
+
+	switch {
+	case g.present && (!wasPresent || g.name != previous):
+		mapping, found := MappingFor(g.name)
+		g.mapping = mapping
+		if found {
+			log.Printf("gamepad %q connected", g.name)
+		} else {
+			log.Printf("gamepad %q connected, no mapping for it, assuming an Xbox 360 layout", g.name)
+		}
+		buttons := len(g.joysticks.JoystickButtons(g.joystick))
+		switch {
+		case mapping.UsesHats() && !hatsAsButtons:
+			log.Printf("the mapping of %q reads hats, which GLFW 3.2 does not report as buttons on this system, they stay released", g.name)
+		case mapping.hats*len(hatDirections) > buttons:
+			log.Printf("the mapping of %q reads %d hat(s) but the joystick has only %d buttons, the hats stay released", g.name, mapping.hats, buttons)
+		}
+	case !g.present && wasPresent:
+		log.Printf("gamepad %q disconnected", previous)
+	}
+}
+
+// Poll - Samples the joystick
+func (g *Gamepad) Poll() {
+	if g == nil {
+		return
+	}
+	if g.rescan {
+		g.scan()
+	}
+
+	g.axes, g.buttons = [gamepadAxes]float64{}, [gamepadButtons]bool{}
+	if !g.present {
+		return
+	}
+	axes, buttons := g.joysticks.JoystickAxes(g.joystick), g.joysticks.JoystickButtons(g.joystick)
+	if axes == nil && buttons == nil {
+		// Gone before the callback said so
+		g.rescan = true
+		return
+	}
+
+	raw, pressed := g.mapping.read(axes, buttons)
+	g.axes[LeftX], g.axes[LeftY] = g.stick(raw[LeftX], raw[LeftY])
+	g.axes[RightX], g.axes[RightY] = g.stick(raw[RightX], raw[RightY])
+	g.axes[LeftTrigger] = g.response(raw[LeftTrigger])
+	g.axes[RightTrigger] = g.response(raw[RightTrigger])
+	g.buttons = pressed
+}
+
+// stick - Applies the deadzone and curve to the length of a stick's deflection
+func (g *Gamepad) stick(x, y float64) (float64, float64) {
+	length := math.Hypot(x, y)
+	if length == 0 {
+		return 0, 0
+	}
+	scale := g.response(math.Min(length, 1)) / length
+	return x * scale, y * scale
+}
+
+// response - Maps a deflection from 0 to 1 past the deadzone onto the curve
+func (g *Gamepad) response(v float64) float64 {
+	if v <= g.Deadzone || g.Deadzone >= 1 {
+		return 0
+	}
+	v = (v - g.Deadzone) / (1 - g.Deadzone)
+	if g.Curve > 0 {
+		v = math.Pow(v, g.Curve)
+	}
+	return v
+}
+
+// Connected - Whether a joystick was present at the last poll
+func (g *Gamepad) Connected() bool {
+	return g != nil && g.present
+}
+
+// Axis - An axis at the last poll, zero without a joystick
+func (g *Gamepad) Axis(axis GamepadAxis) float64 {
+	if g == nil {
+		return 0
+	}
+	return g.axes[axis]
+}
+
+// Button - Whether a button was down at the last poll
+func (g *Gamepad) Button(button GamepadButton) bool {
+	return g != nil && g.buttons[button]
+}
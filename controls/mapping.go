@@ -0,0 +1,369 @@
+package controls
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// GamepadAxis - An axis of the standard gamepad layout SDL_GameControllerDB mappings map to
+type GamepadAxis int
+
+// Axes of the standard layout. Sticks go from -1 to 1, positive right and down; triggers go from
+// 0 at rest to 1.
+const (
+	LeftX GamepadAxis = iota
+	LeftY
+	RightX
+	RightY
+	LeftTrigger
+	RightTrigger
+	gamepadAxes
+)
+
+// GamepadButton - A button of the standard gamepad layout
+type GamepadButton int
+
+// Buttons of the standard layout, named by their position on an Xbox controller
+const (
+	ButtonA GamepadButton = iota
+	ButtonB
+	ButtonX
+	ButtonY
+	ButtonBack
+	ButtonGuide
+	ButtonStart
+	ButtonLeftStick
+	ButtonRightStick
+	ButtonLeftShoulder
+	ButtonRightShoulder
+	ButtonDpadUp
+	ButtonDpadDown
+	ButtonDpadLeft
+	ButtonDpadRight
+	gamepadButtons
+)
+
+// The field names of the layout in mapping strings
+var (
+	axisFields = map[string]GamepadAxis{
+		"leftx": LeftX, "lefty": LeftY, "rightx": RightX, "righty": RightY,
+		"lefttrigger": LeftTrigger, "righttrigger": RightTrigger,
+	}
+	buttonFields = map[string]GamepadButton{
+		"a": ButtonA, "b": ButtonB, "x": ButtonX, "y": ButtonY,
+		"back": ButtonBack, "guide": ButtonGuide, "start": ButtonStart,
+		"leftstick": ButtonLeftStick, "rightstick": ButtonRightStick,
+		"leftshoulder": ButtonLeftShoulder, "rightshoulder": ButtonRightShoulder,
+		"dpup": ButtonDpadUp, "dpdown": ButtonDpadDown, "dpleft": ButtonDpadLeft, "dpright": ButtonDpadRight,
+	}
+)
+
+// Mapping - How the raw axes and buttons of one kind of joystick make up the standard layout,
+// read from a line of SDL_GameControllerDB:
+//
+//	GUID,Name,a:b0,leftx:a0,lefttrigger:+a2,dpup:-a7,righty:a4~,platform:Linux,
+//
+// An element is a raw axis aN, button bN or hat hN.MASK. A leading + or - on an axis uses only
+// that half of its range, a trailing ~ inverts it, and a leading + or - on a field fills only
+// that half of the output. Fields the layout does not have, like paddles, are ignored.
+//
+// GLFW 3.2 has no hats of its own. On Windows and macOS it reports every hat as four more
+// buttons after the real ones, up, right, down and left, which is where hat elements are read
+// from. On Linux it reports hats as axes instead, so hat elements are never pressed there.
+type Mapping struct {
+	GUID, Name, Platform string
+
+	axes    [gamepadAxes][]element
+	buttons [gamepadButtons][]element
+
+	// hats is how many hats the elements use, they are the last buttons of the joystick
+	hats int
+}
+
+// hatsAsButtons - Whether GLFW reports hats as buttons on this system
+var hatsAsButtons = runtime.GOOS != "linux"
+
+// hatDirections - The hat masks SDL uses, in the order GLFW reports their buttons
+var hatDirections = []int{1, 2, 4, 8}
+
+type elementKind int
+
+const (
+	rawAxis elementKind = iota
+	rawButton
+	rawHat
+)
+
+// element - One raw input of a mapping field
+type element struct {
+	kind  elementKind
+	index int
+
+	// Axes are scaled and offset into -1 to 1
+	scale, offset float64
+
+	// half is +1 or -1 for fields that only fill that half of the output
+	half float64
+}
+
+// ParseMapping - Parses one line of SDL_GameControllerDB
+func ParseMapping(line string) (*Mapping, error) {
+	fields := strings.Split(strings.TrimSpace(line), ",")
+	if len(fields) < 2 || fields[0] == "" {
+		return nil, fmt.Errorf("expected GUID,name,mappings..., got %q", line)
+	}
+
+	m := &Mapping{GUID: fields[0], Name: fields[1]}
+	for _, field := range fields[2:] {
+		if field == "" {
+			continue
+		}
+		colon := strings.Index(field, ":")
+		if colon < 0 {
+			return nil, fmt.Errorf("%s: expected name:element, got %q", m.Name, field)
+		}
+		name, value := field[:colon], field[colon+1:]
+		if name == "platform" {
+			m.Platform = value
+			continue
+		}
+
+		var half float64
+		switch {
+		case strings.HasPrefix(name, "+"):
+			half, name = 1, name[1:]
+		case strings.HasPrefix(name, "-"):
+			half, name = -1, name[1:]
+		}
+		axis, isAxis := axisFields[name]
+		button, isButton := buttonFields[name]
+		if !isAxis && !isButton {
+			continue
+		}
+
+		e, err := parseElement(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %v", m.Name, field, err)
+		}
+		e.half = half
+		if e.kind == rawHat && e.index/len(hatDirections) >= m.hats {
+			m.hats = e.index/len(hatDirections) + 1
+		}
+		if isAxis {
+			m.axes[axis] = append(m.axes[axis], e)
+		} else {
+			m.buttons[button] = append(m.buttons[button], e)
+		}
+	}
+	return m, nil
+}
+
+func parseElement(s string) (element, error) {
+	// Map the used range of a raw axis onto -1 to 1, a half range starting at its middle
+	e := element{scale: 1}
+	switch {
+	case strings.HasPrefix(s, "+"):
+		e.scale, e.offset, s = 2, -1, s[1:]
+	case strings.HasPrefix(s, "-"):
+		e.scale, e.offset, s = -2, -1, s[1:]
+	}
+	invert := strings.HasSuffix(s, "~")
+	s = strings.TrimSuffix(s, "~")
+	if s == "" {
+		return element{}, fmt.Errorf("empty element")
+	}
+
+	switch s[0] {
+	case 'a':
+		e.kind = rawAxis
+	case 'b':
+		e.kind = rawButton
+	case 'h':
+		return parseHat(s, e)
+	default:
+		return element{}, fmt.Errorf("expected aN, bN or hN.MASK, got %q", s)
+	}
+	index, err := strconv.Atoi(s[1:])
+	if err != nil || index < 0 {
+		return element{}, fmt.Errorf("invalid index in %q", s)
+	}
+	e.index = index
+
+	if invert {
+		e.scale, e.offset = -e.scale, -e.offset
+	}
+	return e, nil
+}
+
+// parseHat - Parses hN.MASK into the index of its button among the hat buttons, four per hat
+func parseHat(s string, e element) (element, error) {
+	dot := strings.Index(s, ".")
+	if dot < 0 {
+		return element{}, fmt.Errorf("expected hN.MASK, got %q", s)
+	}
+	hat, err := strconv.Atoi(s[1:dot])
+	if err != nil || hat < 0 {
+		return element{}, fmt.Errorf("invalid hat in %q", s)
+	}
+	mask, err := strconv.Atoi(s[dot+1:])
+	if err != nil {
+		return element{}, fmt.Errorf("invalid hat mask in %q", s)
+	}
+	for direction, bit := range hatDirections {
+		if mask == bit {
+			e.kind, e.index = rawHat, hat*len(hatDirections)+direction
+			return e, nil
+		}
+	}
+	return element{}, fmt.Errorf("hat mask in %q is not one of 1, 2, 4 or 8", s)
+}
+
+// value - The element from -1 to 1, buttons being -1 up and 1 down. The hat buttons start at
+// firstHat.
+func (e element) value(axes []float32, buttons []byte, firstHat int) float64 {
+	switch e.kind {
+	case rawAxis:
+		if e.index < len(axes) {
+			return clamp(float64(axes[e.index])*e.scale+e.offset, -1, 1)
+		}
+	case rawButton:
+		if e.index < len(buttons) && buttons[e.index] != 0 {
+			return 1
+		}
+	case rawHat:
+		if index := firstHat + e.index; hatsAsButtons && firstHat >= 0 && index < len(buttons) && buttons[index] != 0 {
+			return 1
+		}
+	}
+	return -1
+}
+
+// UsesHats - Whether the mapping reads a hat, which GLFW 3.2 cannot report on every system
+func (m *Mapping) UsesHats() bool {
+	return m.hats > 0
+}
+
+// read - The standard layout from the raw state of a joystick, before any deadzone
+func (m *Mapping) read(axes []float32, buttons []byte) (state [gamepadAxes]float64, pressed [gamepadButtons]bool) {
+	firstHat := len(buttons) - m.hats*len(hatDirections)
+	for axis, elements := range m.axes {
+		trigger := GamepadAxis(axis) == LeftTrigger || GamepadAxis(axis) == RightTrigger
+		for _, e := range elements {
+			v := e.value(axes, buttons, firstHat)
+			switch {
+			case e.half != 0:
+				v = e.half * (v + 1) / 2
+			case trigger:
+				v = (v + 1) / 2
+			}
+			// Several elements of one field each move it, the furthest wins
+			if abs(v) > abs(state[axis]) {
+				state[axis] = v
+			}
+		}
+	}
+	for button, elements := range m.buttons {
+		for _, e := range elements {
+			pressed[button] = pressed[button] || e.value(axes, buttons, firstHat) > 0.5
+		}
+	}
+	return state, pressed
+}
+
+// The mappings added so far, by lower case joystick name. GLFW 3.2 does not report joystick
+// GUIDs, so the name is all a joystick can be matched by.
+var mappings = struct {
+	sync.Mutex
+	byName map[string]*Mapping
+}{byName: make(map[string]*Mapping)}
+
+// platforms - SDL's names for the systems Go runs on
+var platforms = map[string]string{
+	"linux": "Linux", "windows": "Windows", "darwin": "Mac OS X", "android": "Android", "ios": "iOS",
+}
+
+// AddMappings - Adds the mappings of an SDL_GameControllerDB file, skipping comments and mappings
+// of other platforms, and returns how many were added. Later mappings replace earlier ones of the
+// same name.
+func AddMappings(r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	added := 0
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		m, err := ParseMapping(text)
+		if err != nil {
+			return added, fmt.Errorf("line %d: %v", line, err)
+		}
+		if m.Platform != "" && m.Platform != platforms[runtime.GOOS] {
+			continue
+		}
+		AddMapping(m)
+		added++
+	}
+	return added, scanner.Err()
+}
+
+// AddMappingsFromFile - Adds the mappings of an SDL_GameControllerDB file
+func AddMappingsFromFile(file string) (int, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	added, err := AddMappings(f)
+	if err != nil {
+		return added, fmt.Errorf("%s: %v", file, err)
+	}
+	return added, nil
+}
+
+// AddMapping - Adds one mapping
+func AddMapping(m *Mapping) {
+	mappings.Lock()
+	defer mappings.Unlock()
+	mappings.byName[strings.ToLower(m.Name)] = m
+}
+
+// MappingFor - The mapping of the joystick called name, or the layout of an Xbox 360 controller
+// as GLFW reports it when there is none
+func MappingFor(name string) (*Mapping, bool) {
+	mappings.Lock()
+	defer mappings.Unlock()
+	if m, ok := mappings.byName[strings.ToLower(name)]; ok {
+		return m, true
+	}
+	return defaultMapping, false
+}
+
+// defaultMapping - An Xbox 360 controller, whose driver reports the d-pad as axes 6 and 7
+var defaultMapping, _ = ParseMapping("xinput,XInput Controller," +
+	"a:b0,b:b1,x:b2,y:b3,back:b6,start:b7,guide:b8,leftshoulder:b4,rightshoulder:b5," +
+	"leftstick:b9,rightstick:b10,leftx:a0,lefty:a1,rightx:a3,righty:a4," +
+	"lefttrigger:a2,righttrigger:a5,dpleft:-a6,dpright:+a6,dpup:-a7,dpdown:+a7,")
+
+func clamp(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
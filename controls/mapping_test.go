@@ -0,0 +1,282 @@
+package controls
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// Lines as SDL_GameControllerDB has them
+const (
+	// Linux reports the d-pad of an Xbox 360 controller as a hat
+	xbox360Linux = "030000005e0400008e02000014010000,Xbox 360 Controller,a:b0,b:b1,back:b6,dpdown:h0.4,dpleft:h0.8,dpright:h0.2,dpup:h0.1,guide:b8,leftshoulder:b4,leftstick:b9,lefttrigger:a2,leftx:a0,lefty:a1,rightshoulder:b5,rightstick:b10,righttrigger:a5,rightx:a3,righty:a4,start:b7,x:b2,y:b3,platform:Linux,"
+
+	// DirectInput reports both triggers of an Xbox 360 controller on one axis
+	xbox360DirectInput = "030000005e0400008e02000000000000,Xbox 360 Controller (DirectInput),a:b0,b:b1,back:b6,dpdown:h0.4,dpleft:h0.8,dpright:h0.2,dpup:h0.1,leftshoulder:b4,leftstick:b8,lefttrigger:+a2,leftx:a0,lefty:a1,rightshoulder:b5,rightstick:b9,righttrigger:-a2,rightx:a3,righty:a4,start:b7,x:b2,y:b3,platform:Windows,"
+
+	// A PS4 controller on macOS, with a touchpad the standard layout does not have
+	ps4Mac = "030000004c050000c405000000010000,PS4 Controller,a:b1,b:b2,back:b8,dpdown:h0.4,dpleft:h0.8,dpright:h0.2,dpup:h0.1,guide:b12,leftshoulder:b4,leftstick:b10,lefttrigger:a3,leftx:a0,lefty:a1,rightshoulder:b5,rightstick:b11,righttrigger:a4,rightx:a2,righty:a5,start:b9,x:b0,y:b3,touchpad:b13,platform:Mac OS X,"
+
+	// A gamepad whose right stick reports up as positive
+	invertedRightY = "03000000790000000600000000000000,G-Shark GS-GP702,a:b2,b:b1,back:b8,dpdown:h0.4,dpleft:h0.8,dpright:h0.2,dpup:h0.1,leftshoulder:b4,leftstick:b10,lefttrigger:b6,leftx:a0,lefty:a1,rightshoulder:b5,rightstick:b11,righttrigger:b7,rightx:a2,righty:a4~,start:b9,x:b3,y:b0,platform:Windows,"
+
+	// A fighting stick whose lever is a hat, filling the halves of the left stick
+	arcadeStick = "030000000d0f00008500000000000000,HORI Fighting Commander,+leftx:h0.2,+lefty:h0.4,-leftx:h0.8,-lefty:h0.1,a:b0,b:b2,back:b8,leftshoulder:b4,lefttrigger:b6,rightshoulder:b5,righttrigger:b7,start:b9,x:b1,y:b3,platform:Windows,"
+)
+
+// withHatButtons - Runs f as if GLFW reported hats as buttons or not, whatever the system
+func withHatButtons(asButtons bool, f func()) {
+	saved := hatsAsButtons
+	hatsAsButtons = asButtons
+	defer func() { hatsAsButtons = saved }()
+	f()
+}
+
+func TestElementValue(t *testing.T) {
+	// Six buttons, the last four being the hat
+	buttons := []byte{0, 1, 1, 0, 0, 0}
+	const firstHat = 2
+	tests := []struct {
+		element string
+		axes    []float32
+		want    float64
+	}{
+		{"a0", []float32{0.5}, 0.5},
+		{"a0~", []float32{0.5}, -0.5},
+		{"a1", []float32{0}, -1},
+
+		// A half axis from its middle to one end fills the whole range
+		{"+a0", []float32{1}, 1},
+		{"+a0", []float32{0.5}, 0},
+		{"+a0", []float32{0}, -1},
+		{"+a0", []float32{-1}, -1},
+		{"-a0", []float32{-1}, 1},
+		{"-a0", []float32{-0.5}, 0},
+		{"-a0", []float32{0.5}, -1},
+		{"+a0~", []float32{1}, -1},
+		{"+a0~", []float32{0}, 1},
+
+		{"b1", nil, 1},
+		{"b0", nil, -1},
+		{"b6", nil, -1},
+
+		// Up, right, down and left are the hat buttons in that order
+		{"h0.1", nil, 1},
+		{"h0.2", nil, -1},
+		{"h0.4", nil, -1},
+		{"h1.1", nil, -1},
+	}
+	withHatButtons(true, func() {
+		for _, test := range tests {
+			e, err := parseElement(test.element)
+			if err != nil {
+				t.Errorf("%s: %v", test.element, err)
+				continue
+			}
+			if got := e.value(test.axes, buttons, firstHat); got != test.want {
+				t.Errorf("%s of %v and %v is %v, want %v", test.element, test.axes, buttons, got, test.want)
+			}
+		}
+	})
+
+	// Without hats among the buttons they are never pressed
+	withHatButtons(false, func() {
+		e, _ := parseElement("h0.1")
+		if got := e.value(nil, buttons, firstHat); got != -1 {
+			t.Errorf("h0.1 is %v where hats are not buttons, want -1", got)
+		}
+	})
+}
+
+func TestMappingRead(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		asButtons  bool
+		axes       []float32
+		buttons    []byte
+		want       map[GamepadAxis]float64
+		wantButton []GamepadButton
+	}{
+		{
+			"triggers rest at zero", xbox360Linux, true,
+			[]float32{0.25, -0.5, -1, 0, 0, 1}, make([]byte, 15),
+			map[GamepadAxis]float64{LeftX: 0.25, LeftY: -0.5, LeftTrigger: 0, RightTrigger: 1}, nil,
+		},
+		{
+			// The hat is read from the four buttons after the eleven real ones
+			"hat buttons", xbox360Linux, true,
+			make([]float32, 6), []byte{1, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 1},
+			nil, []GamepadButton{ButtonA, ButtonStart, ButtonDpadUp, ButtonDpadLeft},
+		},
+		{
+			"hats not reported", xbox360Linux, false,
+			make([]float32, 6), []byte{1, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 1},
+			nil, []GamepadButton{ButtonA, ButtonStart},
+		},
+		{
+			"shared trigger axis pulled left", xbox360DirectInput, true,
+			[]float32{0, 0, 1, 0, 0}, make([]byte, 14),
+			map[GamepadAxis]float64{LeftTrigger: 1, RightTrigger: 0}, nil,
+		},
+		{
+			"shared trigger axis pulled right halfway", xbox360DirectInput, true,
+			[]float32{0, 0, -0.5, 0, 0}, make([]byte, 14),
+			map[GamepadAxis]float64{LeftTrigger: 0, RightTrigger: 0.5}, nil,
+		},
+		{
+			"shared trigger axis at rest", xbox360DirectInput, true,
+			[]float32{0, 0, 0, 0, 0}, make([]byte, 14),
+			map[GamepadAxis]float64{LeftTrigger: 0, RightTrigger: 0}, nil,
+		},
+		{
+			"inverted axis", invertedRightY, true,
+			[]float32{0, 0, 0.5, 0, 0.75}, make([]byte, 16),
+			map[GamepadAxis]float64{RightX: 0.5, RightY: -0.75}, nil,
+		},
+		{
+			"extra buttons are ignored", ps4Mac, true,
+			make([]float32, 6), append([]byte{0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, 0, 0, 0, 0),
+			nil, []GamepadButton{ButtonA},
+		},
+		{
+			"lever right and up", arcadeStick, true,
+			nil, []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 0, 0},
+			map[GamepadAxis]float64{LeftX: 1, LeftY: -1}, nil,
+		},
+		{
+			"lever left", arcadeStick, true,
+			nil, []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+			map[GamepadAxis]float64{LeftX: -1, LeftY: 0}, nil,
+		},
+		{
+			"lever centred", arcadeStick, true,
+			nil, make([]byte, 14),
+			map[GamepadAxis]float64{LeftX: 0, LeftY: 0}, nil,
+		},
+	}
+	for _, test := range tests {
+		m, err := ParseMapping(test.line)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		withHatButtons(test.asButtons, func() {
+			state, pressed := m.read(test.axes, test.buttons)
+			for axis, want := range test.want {
+				if state[axis] != want {
+					t.Errorf("%s: axis %v is %v, want %v", test.name, padAxisNames[axis], state[axis], want)
+				}
+			}
+			for button := range pressed {
+				want := false
+				for _, b := range test.wantButton {
+					want = want || GamepadButton(button) == b
+				}
+				if pressed[button] != want {
+					t.Errorf("%s: %s pressed is %v, want %v", test.name, padButtonNames[GamepadButton(button)], pressed[button], want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseMapping(t *testing.T) {
+	m, err := ParseMapping(ps4Mac)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.GUID != "030000004c050000c405000000010000" || m.Name != "PS4 Controller" || m.Platform != "Mac OS X" {
+		t.Errorf("parsed %q %q on %q", m.GUID, m.Name, m.Platform)
+	}
+	if !m.UsesHats() || m.hats != 1 {
+		t.Errorf("uses %d hats, want 1", m.hats)
+	}
+	if m, _ := ParseMapping(xbox360DirectInput); m.axes[LeftTrigger][0].half != 0 {
+		t.Errorf("a half input axis made a half output")
+	}
+	if m, _ := ParseMapping(arcadeStick); len(m.axes[LeftX]) != 2 || m.axes[LeftX][0].half != 1 || m.axes[LeftX][1].half != -1 {
+		t.Errorf("the halves of leftx parsed as %+v", m.axes[LeftX])
+	}
+	if m, _ := ParseMapping("guid,Two Hats,dpup:h1.1,"); m.hats != 2 {
+		t.Errorf("a mapping reading hat 1 uses %d hats, want 2", m.hats)
+	}
+
+	errors := []struct {
+		line string
+		want string
+	}{
+		{"", "expected GUID,name,mappings..."},
+		{"030000005e0400008e02000014010000", "expected GUID,name,mappings..."},
+		{",Nameless,a:b0", "expected GUID,name,mappings..."},
+		{"guid,Pad,a", `expected name:element, got "a"`},
+		{"guid,Pad,a:", "empty element"},
+		{"guid,Pad,a:x0", "expected aN, bN or hN.MASK"},
+		{"guid,Pad,a:bx", "invalid index"},
+		{"guid,Pad,leftx:a-1", "invalid index"},
+		{"guid,Pad,dpup:h0", "expected hN.MASK"},
+		{"guid,Pad,dpup:hx.1", "invalid hat"},
+		{"guid,Pad,dpup:h0.x", "invalid hat mask"},
+		{"guid,Pad,dpup:h0.3", "not one of 1, 2, 4 or 8"},
+	}
+	for _, test := range errors {
+		if _, err := ParseMapping(test.line); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%q: got error %v, want one containing %q", test.line, err, test.want)
+		}
+	}
+}
+
+func TestAddMappings(t *testing.T) {
+	db := strings.Join([]string{
+		"# Game Controller DB for SDL",
+		"",
+		"# Linux",
+		strings.Replace(xbox360Linux, "Xbox 360 Controller", "Test Linux Pad", 1),
+		"# Windows",
+		strings.Replace(xbox360DirectInput, "Xbox 360 Controller (DirectInput)", "Test Windows Pad", 1),
+		"# Mac OS X",
+		strings.Replace(ps4Mac, "PS4 Controller", "Test Mac Pad", 1),
+		"# Any platform",
+		"guid,Test Any Pad,a:b0,",
+	}, "\n")
+	names := map[string]string{
+		"Test Linux Pad": "Linux", "Test Windows Pad": "Windows", "Test Mac Pad": "Mac OS X", "Test Any Pad": "",
+	}
+	defer func() {
+		mappings.Lock()
+		defer mappings.Unlock()
+		for name := range names {
+			delete(mappings.byName, strings.ToLower(name))
+		}
+	}()
+
+	added, err := AddMappings(strings.NewReader(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := 0
+	for name, platform := range names {
+		ours := platform == "" || platform == platforms[runtime.GOOS]
+		if ours {
+			want++
+		}
+		// Names match whatever their case
+		m, found := MappingFor(strings.ToUpper(name))
+		if found != ours {
+			t.Errorf("%s: found is %v on %s", name, found, runtime.GOOS)
+		}
+		if found && m.Name != name {
+			t.Errorf("%s: found the mapping of %s", name, m.Name)
+		}
+		if !found && m != defaultMapping {
+			t.Errorf("%s: not found but not the default mapping either", name)
+		}
+	}
+	if added != want {
+		t.Errorf("added %d mappings, want %d", added, want)
+	}
+
+	if _, err := AddMappings(strings.NewReader("# ok\nguid,Pad,a:q1,\n")); err == nil || !strings.Contains(err.Error(), "line 2:") {
+		t.Errorf("got error %v, want one on line 2", err)
+	}
+}
@@ -0,0 +1,256 @@
+package controls
+
+import (
+	"fmt"
+	"strings"
+)
+
+// control - One named key, mouse button or gamepad button. Names like Shift stand for either of
+// the keys.
+type control struct {
+	name    string
+	keys    []Key
+	buttons []MouseButton
+	pad     []GamepadButton
+
+	// trigger is a gamepad trigger that counts as down when pulled halfway, if it is not LeftX
+	trigger GamepadAxis
+}
+
+func (c control) down(device *Device) bool {
+	for _, button := range c.pad {
+		if device.PadButton(button) {
+			return true
+		}
+	}
+	if c.trigger != LeftX && device.PadAxis(c.trigger) > 0.5 {
+		return true
+	}
+	for _, key := range c.keys {
+		if device.Key(key) {
+			return true
+		}
+	}
+	for _, button := range c.buttons {
+		if device.MouseButton(button) {
+			return true
+		}
+	}
+	return false
+}
+
+// axis - A scroll direction, valued in notches since the last poll
+type axis int
+
+const (
+	noAxis axis = iota
+	// ScrollX and ScrollY are signed, the others only count notches in their direction
+	scrollX
+	scrollY
+	scrollUp
+	scrollDown
+	scrollLeft
+	scrollRight
+)
+
+var axisNames = map[axis]string{
+	scrollX:     "ScrollX",
+	scrollY:     "ScrollY",
+	scrollUp:    "ScrollUp",
+	scrollDown:  "ScrollDown",
+	scrollLeft:  "ScrollLeft",
+	scrollRight: "ScrollRight",
+}
+
+func parseAxis(name string) (axis, bool) {
+	for a, axisName := range axisNames {
+		if strings.EqualFold(name, axisName) {
+			return a, true
+		}
+	}
+	return noAxis, false
+}
+
+func (a axis) value(x, y float64) float64 {
+	switch a {
+	case scrollX:
+		return x
+	case scrollY:
+		return y
+	case scrollUp:
+		return positive(y)
+	case scrollDown:
+		return positive(-y)
+	case scrollLeft:
+		return positive(-x)
+	case scrollRight:
+		return positive(x)
+	}
+	return 0
+}
+
+func positive(v float64) float64 {
+	if v > 0 {
+		return v
+	}
+	return 0
+}
+
+// Binding - One way to trigger an action: a chord of keys and mouse buttons that all have to be
+// held, optionally ending in a scroll axis, written like "W", "Ctrl+Shift+Z", "MouseRight" or
+// "Alt+ScrollY"
+type Binding struct {
+	chord []control
+	axis  axis
+}
+
+// ParseBinding - Parses a binding, ignoring case
+func ParseBinding(s string) (Binding, error) {
+	var b Binding
+	parts := strings.Split(s, "+")
+	for i, part := range parts {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if name == "" {
+			return Binding{}, fmt.Errorf("empty control in %q", s)
+		}
+		if a, ok := parseAxis(name); ok {
+			if i != len(parts)-1 {
+				return Binding{}, fmt.Errorf("%q: a scroll axis has to come last", s)
+			}
+			b.axis = a
+			continue
+		}
+		c, ok := controlNames[name]
+		if !ok {
+			return Binding{}, fmt.Errorf("unknown control %q", strings.TrimSpace(part))
+		}
+		b.chord = append(b.chord, c)
+	}
+	return b, nil
+}
+
+// String - The binding as ParseBinding reads it
+func (b Binding) String() string {
+	var parts []string
+	for _, c := range b.chord {
+		parts = append(parts, c.name)
+	}
+	if b.axis != noAxis {
+		parts = append(parts, axisNames[b.axis])
+	}
+	return strings.Join(parts, "+")
+}
+
+// contains - Whether every control of other is part of b's chord
+func (b Binding) contains(other Binding) bool {
+	for _, c := range other.chord {
+		found := false
+		for _, own := range b.chord {
+			found = found || own.name == c.name
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// controlNames - Every key and button by its lower case name: the GLFW name without the Key
+// prefix, Mouse1 to Mouse8, MouseLeft, MouseRight, MouseMiddle, the modifiers Shift, Ctrl, Alt and
+// Super for either side, and the gamepad's PadA, PadB, PadX, PadY, PadBack, PadGuide, PadStart,
+// PadLeftStick, PadRightStick, PadLeftShoulder, PadRightShoulder, PadUp, PadDown, PadLeft,
+// PadRight, PadLeftTrigger and PadRightTrigger
+var controlNames = map[string]control{}
+
+// The names of single keys, mouse buttons, gamepad buttons and gamepad axes, for recordings
+var (
+	keyNames       = map[Key]string{}
+	buttonNames    = map[MouseButton]string{}
+	padButtonNames = map[GamepadButton]string{}
+	padAxisNames   = map[GamepadAxis]string{
+		LeftX: "LeftX", LeftY: "LeftY", RightX: "RightX", RightY: "RightY",
+		LeftTrigger: "LeftTrigger", RightTrigger: "RightTrigger",
+	}
+)
+
+func init() {
+	keys := map[string]Key{
+		"Space": KeySpace, "Apostrophe": KeyApostrophe, "Comma": KeyComma,
+		"Minus": KeyMinus, "Period": KeyPeriod, "Slash": KeySlash,
+		"Semicolon": KeySemicolon, "Equal": KeyEqual,
+		"LeftBracket": KeyLeftBracket, "Backslash": KeyBackslash,
+		"RightBracket": KeyRightBracket, "GraveAccent": KeyGraveAccent,
+		"World1": KeyWorld1, "World2": KeyWorld2,
+
+		"Escape": KeyEscape, "Enter": KeyEnter, "Tab": KeyTab,
+		"Backspace": KeyBackspace, "Insert": KeyInsert, "Delete": KeyDelete,
+		"Right": KeyRight, "Left": KeyLeft, "Down": KeyDown, "Up": KeyUp,
+		"PageUp": KeyPageUp, "PageDown": KeyPageDown, "Home": KeyHome,
+		"End": KeyEnd, "CapsLock": KeyCapsLock, "ScrollLock": KeyScrollLock,
+		"NumLock": KeyNumLock, "PrintScreen": KeyPrintScreen, "Pause": KeyPause,
+		"Menu": KeyMenu,
+
+		"KPDecimal": KeyKPDecimal, "KPDivide": KeyKPDivide,
+		"KPMultiply": KeyKPMultiply, "KPSubtract": KeyKPSubtract,
+		"KPAdd": KeyKPAdd, "KPEnter": KeyKPEnter, "KPEqual": KeyKPEqual,
+
+		"LeftShift": KeyLeftShift, "LeftControl": KeyLeftControl,
+		"LeftAlt": KeyLeftAlt, "LeftSuper": KeyLeftSuper,
+		"RightShift": KeyRightShift, "RightControl": KeyRightControl,
+		"RightAlt": KeyRightAlt, "RightSuper": KeyRightSuper,
+	}
+	// The key constants of letters, digits and function keys are consecutive
+	for i := 0; i < 26; i++ {
+		keys[string(rune('A'+i))] = KeyA + Key(i)
+	}
+	for i := 0; i < 10; i++ {
+		keys[fmt.Sprint(i)] = Key0 + Key(i)
+		keys[fmt.Sprint("KP", i)] = KeyKP0 + Key(i)
+	}
+	for i := 1; i <= 25; i++ {
+		keys[fmt.Sprint("F", i)] = KeyF1 + Key(i-1)
+	}
+	for name, key := range keys {
+		controlNames[strings.ToLower(name)] = control{name: name, keys: []Key{key}}
+		keyNames[key] = name
+	}
+
+	modifiers := map[string][]Key{
+		"Shift": {KeyLeftShift, KeyRightShift},
+		"Ctrl":  {KeyLeftControl, KeyRightControl},
+		"Alt":   {KeyLeftAlt, KeyRightAlt},
+		"Super": {KeyLeftSuper, KeyRightSuper},
+	}
+	for name, keys := range modifiers {
+		controlNames[strings.ToLower(name)] = control{name: name, keys: keys}
+	}
+
+	buttons := map[string]MouseButton{
+		"MouseLeft": MouseButtonLeft, "MouseRight": MouseButtonRight,
+		"MouseMiddle": MouseButtonMiddle,
+	}
+	for i := 0; i < 8; i++ {
+		buttons[fmt.Sprint("Mouse", i+1)] = MouseButton1 + MouseButton(i)
+	}
+	for name, button := range buttons {
+		controlNames[strings.ToLower(name)] = control{name: name, buttons: []MouseButton{button}}
+	}
+	// Mouse1 to Mouse3 have two names, recordings use the numbers
+	for i := 0; i < 8; i++ {
+		buttonNames[MouseButton1+MouseButton(i)] = fmt.Sprint("Mouse", i+1)
+	}
+
+	pad := map[string]GamepadButton{
+		"PadA": ButtonA, "PadB": ButtonB, "PadX": ButtonX, "PadY": ButtonY,
+		"PadBack": ButtonBack, "PadGuide": ButtonGuide, "PadStart": ButtonStart,
+		"PadLeftStick": ButtonLeftStick, "PadRightStick": ButtonRightStick,
+		"PadLeftShoulder": ButtonLeftShoulder, "PadRightShoulder": ButtonRightShoulder,
+		"PadUp": ButtonDpadUp, "PadDown": ButtonDpadDown, "PadLeft": ButtonDpadLeft, "PadRight": ButtonDpadRight,
+	}
+	for name, button := range pad {
+		controlNames[strings.ToLower(name)] = control{name: name, pad: []GamepadButton{button}}
+		padButtonNames[button] = name
+	}
+	controlNames["padlefttrigger"] = control{name: "PadLeftTrigger", trigger: LeftTrigger}
+	controlNames["padrighttrigger"] = control{name: "PadRightTrigger", trigger: RightTrigger}
+}
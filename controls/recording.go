@@ -0,0 +1,290 @@
+package controls
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Recordings are text with one change per line after a header naming the format version and the
+// time step they were recorded at:
+//
+//	gogl-input 1 0.016666666666666666
+//	1 cursor 512 384
+//	12 +W
+//	15 scroll 0 1
+//	30 -W
+//	31 LeftX 0.53
+//	31 +PadA
+//	90 end
+//
+// Every line starts with the update it happened in, counting from 1. Keys, mouse buttons and
+// gamepad buttons are named like in bindings, with + when they go down and - when they come up.
+// The cursor and the gamepad axes are written when they change, the scroll wheel whenever it moved.
+// Numbers are written exactly, so replaying them reproduces the recorded camera path bit for bit.
+const recordingHeader = "gogl-input 1"
+
+type recording struct {
+	w     *bufio.Writer
+	start int
+	last  frame
+}
+
+// Record - Writes every poll from the next one on to w until StopRecording. timeStep is the
+// fixed time step the polls are updated with, which a replay has to use as well.
+func (d *Device) Record(w io.Writer, timeStep float64) error {
+	if d.recording != nil {
+		return fmt.Errorf("already recording")
+	}
+	r := &recording{w: bufio.NewWriter(w), start: d.tick, last: newFrame()}
+	if _, err := fmt.Fprintf(r.w, "%s %s\n", recordingHeader, formatFloat(timeStep)); err != nil {
+		return err
+	}
+	d.recording = r
+	return nil
+}
+
+// StopRecording - Marks the end of the recording and flushes it
+func (d *Device) StopRecording() error {
+	r := d.recording
+	if r == nil {
+		return nil
+	}
+	d.recording = nil
+	fmt.Fprintf(r.w, "%d end\n", d.tick-r.start)
+	return r.w.Flush()
+}
+
+// write - Writes what changed since the last poll. Write errors surface when the recording is
+// flushed.
+func (r *recording) write(tick int, f frame) {
+	tick -= r.start
+	var changes []string
+
+	held, wasHeld := f.held(), r.last.held()
+	changes = append(changes, pressChanges(wasHeld, held)...)
+
+	if f.cursorX != r.last.cursorX || f.cursorY != r.last.cursorY {
+		changes = append(changes, "cursor "+formatFloat(f.cursorX)+" "+formatFloat(f.cursorY))
+	}
+	if f.scrollX != 0 || f.scrollY != 0 {
+		changes = append(changes, "scroll "+formatFloat(f.scrollX)+" "+formatFloat(f.scrollY))
+	}
+	for axis, value := range f.padAxes {
+		if value != r.last.padAxes[axis] {
+			changes = append(changes, padAxisNames[GamepadAxis(axis)]+" "+formatFloat(value))
+		}
+	}
+
+	for _, change := range changes {
+		fmt.Fprintf(r.w, "%d %s\n", tick, change)
+	}
+	if len(changes) > 0 {
+		r.last = f.copy()
+	}
+}
+
+// held - The names of the keys, mouse buttons and gamepad buttons that are down
+func (f frame) held() map[string]bool {
+	held := make(map[string]bool)
+	for key := range f.keys {
+		held[keyNames[key]] = true
+	}
+	for button := range f.buttons {
+		held[buttonNames[button]] = true
+	}
+	for button, down := range f.padButtons {
+		if down {
+			held[padButtonNames[GamepadButton(button)]] = true
+		}
+	}
+	return held
+}
+
+// pressChanges - The controls that went down or came up, sorted by name
+func pressChanges(before, after map[string]bool) []string {
+	var changes []string
+	for name := range after {
+		if !before[name] {
+			changes = append(changes, "+"+name)
+		}
+	}
+	for name := range before {
+		if !after[name] {
+			changes = append(changes, "-"+name)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i][1:] < changes[j][1:] })
+	return changes
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+type replay struct {
+	events []replayEvent
+	played int
+	start  int
+	end    int
+}
+
+type replayEvent struct {
+	tick  int
+	apply func(f *frame)
+}
+
+// Replay - Reads a recording and plays it back from the next poll on, instead of the window.
+// It returns the time step the recording was made with.
+func (d *Device) Replay(r io.Reader) (float64, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return 0, err
+		}
+		return 0, fmt.Errorf("empty recording")
+	}
+	header := scanner.Text()
+	if !strings.HasPrefix(header, recordingHeader+" ") {
+		return 0, fmt.Errorf("line 1: expected %q and a time step, got %q", recordingHeader, header)
+	}
+	timeStep, err := strconv.ParseFloat(strings.TrimPrefix(header, recordingHeader+" "), 64)
+	if err != nil || timeStep <= 0 {
+		return 0, fmt.Errorf("line 1: invalid time step in %q", header)
+	}
+
+	p := &replay{start: d.tick}
+	ended := false
+	for line := 2; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if ended {
+			return 0, fmt.Errorf("line %d: changes after the end", line)
+		}
+		event, err := parseEvent(fields)
+		if err != nil {
+			return 0, fmt.Errorf("line %d: %v", line, err)
+		}
+		if event.tick < p.end {
+			return 0, fmt.Errorf("line %d: update %d is out of order after update %d", line, event.tick, p.end)
+		}
+		p.end = event.tick
+		if event.apply != nil {
+			p.events = append(p.events, event)
+		} else {
+			ended = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	// Recordings that were not stopped, because the program crashed, do not say how long they are
+	if !ended {
+		return 0, fmt.Errorf("no end line, the recording was cut short")
+	}
+
+	d.replay = p
+	return timeStep, nil
+}
+
+// Replaying - Whether the polls come from a replay
+func (d *Device) Replaying() bool {
+	return d.replay != nil
+}
+
+// next - Applies the changes of tick to f, or returns false once the replay is over. Whatever
+// the recording still held is let go then, only the cursor stays where it was.
+func (p *replay) next(f *frame, tick int) bool {
+	tick -= p.start
+	if tick > p.end {
+		cursorX, cursorY := f.cursorX, f.cursorY
+		*f = newFrame()
+		f.cursorX, f.cursorY = cursorX, cursorY
+		return false
+	}
+
+	f.scrollX, f.scrollY = 0, 0
+	for ; p.played < len(p.events) && p.events[p.played].tick == tick; p.played++ {
+		p.events[p.played].apply(f)
+	}
+	return true
+}
+
+// parseEvent - Reads the fields of one line. The end of the recording has no apply.
+func parseEvent(fields []string) (replayEvent, error) {
+	tick, err := strconv.Atoi(fields[0])
+	if err != nil || tick < 0 {
+		return replayEvent{}, fmt.Errorf("expected an update number, got %q", fields[0])
+	}
+	event := replayEvent{tick: tick}
+	if len(fields) < 2 {
+		return replayEvent{}, fmt.Errorf("update %d has no change", tick)
+	}
+
+	name, args := fields[1], fields[2:]
+	numbers := make([]float64, len(args))
+	for i, arg := range args {
+		if numbers[i], err = strconv.ParseFloat(arg, 64); err != nil {
+			return replayEvent{}, fmt.Errorf("%s: expected a number, got %q", name, arg)
+		}
+	}
+	expect := func(n int) error {
+		if len(numbers) != n {
+			return fmt.Errorf("%s: expected %d number(s), got %d", name, n, len(numbers))
+		}
+		return nil
+	}
+
+	switch {
+	case name == "end":
+		return event, expect(0)
+	case name == "cursor":
+		event.apply = func(f *frame) { f.cursorX, f.cursorY = numbers[0], numbers[1] }
+		return event, expect(2)
+	case name == "scroll":
+		event.apply = func(f *frame) { f.scrollX, f.scrollY = numbers[0], numbers[1] }
+		return event, expect(2)
+	case strings.HasPrefix(name, "+") || strings.HasPrefix(name, "-"):
+		down := name[0] == '+'
+		c, ok := controlNames[strings.ToLower(name[1:])]
+		switch {
+		case !ok:
+			return replayEvent{}, fmt.Errorf("unknown control %q", name[1:])
+		case len(c.keys) == 1:
+			event.apply = func(f *frame) {
+				if down {
+					f.keys[c.keys[0]] = true
+				} else {
+					delete(f.keys, c.keys[0])
+				}
+			}
+		case len(c.buttons) == 1:
+			event.apply = func(f *frame) {
+				if down {
+					f.buttons[c.buttons[0]] = true
+				} else {
+					delete(f.buttons, c.buttons[0])
+				}
+			}
+		case len(c.pad) == 1:
+			event.apply = func(f *frame) { f.padButtons[c.pad[0]] = down }
+		default:
+			return replayEvent{}, fmt.Errorf("%q is not a single key or button", name[1:])
+		}
+		return event, expect(0)
+	}
+
+	for axis, axisName := range padAxisNames {
+		if strings.EqualFold(name, axisName) {
+			axis := axis
+			event.apply = func(f *frame) { f.padAxes[axis] = numbers[0] }
+			return event, expect(1)
+		}
+	}
+	return replayEvent{}, fmt.Errorf("unknown change %q", name)
+}
@@ -0,0 +1,152 @@
+package controls
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeWindow - A window whose input the test sets directly, with one joystick while it has axes
+type fakeWindow struct {
+	keys    map[Key]bool
+	buttons map[MouseButton]bool
+
+	cursorX, cursorY float64
+	scroll           func(x, y float64)
+
+	axes       []float32
+	padButtons []byte
+	connected  func()
+}
+
+func newFakeWindow() *fakeWindow {
+	return &fakeWindow{keys: make(map[Key]bool), buttons: make(map[MouseButton]bool)}
+}
+
+func (w *fakeWindow) Key(key Key) bool                            { return w.keys[key] }
+func (w *fakeWindow) MouseButton(button MouseButton) bool         { return w.buttons[button] }
+func (w *fakeWindow) Cursor() (float64, float64)                  { return w.cursorX, w.cursorY }
+func (w *fakeWindow) SetScrollCallback(scroll func(x, y float64)) { w.scroll = scroll }
+func (w *fakeWindow) SetCursorFree(free bool)                     {}
+
+func (w *fakeWindow) JoystickPresent(joy int) bool       { return joy == 0 && w.axes != nil }
+func (w *fakeWindow) JoystickName(joy int) string        { return "Test Pad" }
+func (w *fakeWindow) JoystickAxes(joy int) []float32     { return w.axes }
+func (w *fakeWindow) JoystickButtons(joy int) []byte     { return w.padButtons }
+func (w *fakeWindow) SetJoystickCallback(changed func()) { w.connected = changed }
+
+func TestRecordReplay(t *testing.T) {
+	// What changes in the window before each poll
+	script := []func(w *fakeWindow){
+		func(w *fakeWindow) { w.cursorX, w.cursorY = 512, 384 },
+		func(w *fakeWindow) { w.keys[letter('W')] = true },
+		func(w *fakeWindow) { w.keys[KeyLeftShift] = true; w.cursorX += 0.1 },
+		func(w *fakeWindow) { w.scroll(0, 1) },
+		func(w *fakeWindow) {},
+		func(w *fakeWindow) { w.buttons[MouseButtonRight] = true; w.scroll(-0.5, 2); w.scroll(0, 1) },
+		func(w *fakeWindow) {
+			// The default mapping, an Xbox 360 controller
+			w.axes = []float32{0.6, -0.8, -1, 0, 0, 1, 0, 0}
+			w.padButtons = make([]byte, 11)
+			w.padButtons[0] = 1
+			w.connected()
+		},
+		func(w *fakeWindow) { delete(w.keys, letter('W')); w.axes[0] = 1.0 / 3 },
+		func(w *fakeWindow) { w.padButtons[0] = 0; w.axes = []float32{0, 0, -1, 0, 0, -1, 0, 0} },
+		func(w *fakeWindow) { w.buttons = make(map[MouseButton]bool); w.keys = make(map[Key]bool) },
+		func(w *fakeWindow) { w.cursorX, w.cursorY = 0, 1e-9 },
+	}
+
+	w := newFakeWindow()
+	recorder := NewDevice(w)
+	recorder.Attach()
+	// Polls before the recording are not part of it
+	recorder.Poll()
+	recorder.Poll()
+
+	var buffer bytes.Buffer
+	if err := recorder.Record(&buffer, 1.0/60); err != nil {
+		t.Fatal(err)
+	}
+	var recorded []frame
+	for _, step := range script {
+		step(w)
+		recorder.Poll()
+		recorded = append(recorded, recorder.frame.copy())
+	}
+	if err := recorder.StopRecording(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replayed without a window, after some polls of its own
+	player := NewDevice(nil)
+	player.Poll()
+	timeStep, err := player.Replay(strings.NewReader(buffer.String()))
+	if err != nil {
+		t.Fatalf("%v in\n%s", err, buffer.String())
+	}
+	if timeStep != 1.0/60 {
+		t.Errorf("time step is %v, want %v", timeStep, 1.0/60)
+	}
+	for i, want := range recorded {
+		player.Poll()
+		if !player.Replaying() {
+			t.Fatalf("replay finished after %d of %d polls", i, len(recorded))
+		}
+		if !reflect.DeepEqual(player.frame, want) {
+			t.Errorf("poll %d replayed as %+v, want %+v", i+1, player.frame, want)
+		}
+	}
+
+	// Past the end nothing is held any more, and the cursor stays
+	player.Poll()
+	if player.Replaying() {
+		t.Error("replay still running past its end")
+	}
+	last := recorded[len(recorded)-1]
+	if x, y := player.Cursor(); len(player.frame.keys) != 0 || x != last.cursorX || y != last.cursorY {
+		t.Errorf("after the replay %v are held and the cursor is at %v, %v", player.frame.keys, x, y)
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		recording string
+		want      string
+	}{
+		{"empty", "", "empty recording"},
+		{"bad header", "gogl-input 2 0.016\n1 end\n", `line 1: expected "gogl-input 1"`},
+		{"no time step", "gogl-input 1\n1 end\n", "line 1: expected"},
+		{"bad time step", "gogl-input 1 fast\n1 end\n", "line 1: invalid time step"},
+		{"negative time step", "gogl-input 1 -0.1\n1 end\n", "line 1: invalid time step"},
+
+		{"out of order", "gogl-input 1 0.1\n5 +W\n3 -W\n6 end\n", "line 3: update 3 is out of order after update 5"},
+		{"end before changes", "gogl-input 1 0.1\n2 +W\n1 end\n", "line 3: update 1 is out of order"},
+
+		{"bad update", "gogl-input 1 0.1\nfirst +W\n", `line 2: expected an update number, got "first"`},
+		{"negative update", "gogl-input 1 0.1\n-1 +W\n", "expected an update number"},
+		{"no change", "gogl-input 1 0.1\n1\n", "update 1 has no change"},
+		{"unknown change", "gogl-input 1 0.1\n1 jump\n", `unknown change "jump"`},
+		{"unknown control", "gogl-input 1 0.1\n1 +Nope\n", `unknown control "Nope"`},
+		{"modifier", "gogl-input 1 0.1\n1 +Shift\n", `"Shift" is not a single key or button`},
+		{"control with a number", "gogl-input 1 0.1\n1 +W 1\n", "+W: expected 0 number(s), got 1"},
+		{"bad number", "gogl-input 1 0.1\n1 cursor 1 x\n", `cursor: expected a number, got "x"`},
+		{"too few numbers", "gogl-input 1 0.1\n1 scroll 1\n", "scroll: expected 2 number(s), got 1"},
+		{"axis without a value", "gogl-input 1 0.1\n1 LeftX\n", "LeftX: expected 1 number(s), got 0"},
+
+		{"missing end", "gogl-input 1 0.1\n1 +W\n2 -W\n", "no end line"},
+		{"changes after the end", "gogl-input 1 0.1\n1 +W\n2 end\n3 -W\n", "line 4: changes after the end"},
+	}
+	for _, test := range tests {
+		d := NewDevice(nil)
+		_, err := d.Replay(strings.NewReader(test.recording))
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: got error %v, want one containing %q", test.name, err, test.want)
+		}
+		if d.Replaying() {
+			t.Errorf("%s: replaying after an error", test.name)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package controls
+
+// Key - A keyboard key, numbered like GLFW's keys so a window can convert between the two
+type Key int
+
+// The keys there are names for
+const (
+	KeySpace        Key = 32
+	KeyApostrophe   Key = 39
+	KeyComma        Key = 44
+	KeyMinus        Key = 45
+	KeyPeriod       Key = 46
+	KeySlash        Key = 47
+	Key0            Key = 48
+	KeySemicolon    Key = 59
+	KeyEqual        Key = 61
+	KeyA            Key = 65
+	KeyLeftBracket  Key = 91
+	KeyBackslash    Key = 92
+	KeyRightBracket Key = 93
+	KeyGraveAccent  Key = 96
+	KeyWorld1       Key = 161
+	KeyWorld2       Key = 162
+	KeyEscape       Key = 256
+	KeyEnter        Key = 257
+	KeyTab          Key = 258
+	KeyBackspace    Key = 259
+	KeyInsert       Key = 260
+	KeyDelete       Key = 261
+	KeyRight        Key = 262
+	KeyLeft         Key = 263
+	KeyDown         Key = 264
+	KeyUp           Key = 265
+	KeyPageUp       Key = 266
+	KeyPageDown     Key = 267
+	KeyHome         Key = 268
+	KeyEnd          Key = 269
+	KeyCapsLock     Key = 280
+	KeyScrollLock   Key = 281
+	KeyNumLock      Key = 282
+	KeyPrintScreen  Key = 283
+	KeyPause        Key = 284
+	KeyF1           Key = 290
+	KeyKP0          Key = 320
+	KeyKPDecimal    Key = 330
+	KeyKPDivide     Key = 331
+	KeyKPMultiply   Key = 332
+	KeyKPSubtract   Key = 333
+	KeyKPAdd        Key = 334
+	KeyKPEnter      Key = 335
+	KeyKPEqual      Key = 336
+	KeyLeftShift    Key = 340
+	KeyLeftControl  Key = 341
+	KeyLeftAlt      Key = 342
+	KeyLeftSuper    Key = 343
+	KeyRightShift   Key = 344
+	KeyRightControl Key = 345
+	KeyRightAlt     Key = 346
+	KeyRightSuper   Key = 347
+	KeyMenu         Key = 348
+)
+
+// MouseButton - A mouse button, numbered like GLFW's buttons
+type MouseButton int
+
+// Mouse buttons 1 to 8 are numbered from MouseButton1 on
+const (
+	MouseButton1      MouseButton = 0
+	MouseButtonLeft   MouseButton = 0
+	MouseButtonRight  MouseButton = 1
+	MouseButtonMiddle MouseButton = 2
+)
+
+// Window - The keyboard, mouse and cursor of a window. Devices sample it once per update, scenes
+// steering a camera with the cursor also switch the cursor mode. The window runner implements it
+// over GLFW, so nothing reading input depends on GLFW itself.
+type Window interface {
+	Key(key Key) bool
+	MouseButton(button MouseButton) bool
+
+	// Cursor - Where the cursor is, in screen coordinates
+	Cursor() (float64, float64)
+
+	// SetScrollCallback - Calls scroll with every wheel movement, nil stops calling it
+	SetScrollCallback(scroll func(x, y float64))
+
+	// SetCursorFree - Shows the cursor, or hides and disables it so it reports unbounded motion
+	// instead of stopping at the edge of the screen
+	SetCursorFree(free bool)
+}
+
+// Joysticks - The joysticks connected to the system, numbered from 0 to MaxJoysticks-1 like
+// GLFW's. A Window that also implements Joysticks gives its device a gamepad.
+type Joysticks interface {
+	JoystickPresent(joy int) bool
+	JoystickName(joy int) string
+	JoystickAxes(joy int) []float32
+	JoystickButtons(joy int) []byte
+
+	// SetJoystickCallback - Calls changed whenever a joystick is connected or disconnected, nil
+	// stops calling it
+	SetJoystickCallback(changed func())
+}
+
+// MaxJoysticks - How many joysticks GLFW tells apart
+const MaxJoysticks = 16
@@ -0,0 +1,378 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+//go:build gl21 && gldebug
+
+package gl
+
+import (
+	"unsafe"
+
+	impl "github.com/go-gl/gl/v2.1/gl"
+)
+
+func ActiveTexture(texture uint32) {
+	impl.ActiveTexture(texture)
+	check("ActiveTexture")
+}
+
+func AttachShader(program uint32, shader uint32) {
+	impl.AttachShader(program, shader)
+	check("AttachShader")
+}
+
+func BindAttribLocation(program uint32, index uint32, name *uint8) {
+	impl.BindAttribLocation(program, index, name)
+	check("BindAttribLocation")
+}
+
+func BindBuffer(target uint32, buffer uint32) {
+	impl.BindBuffer(target, buffer)
+	check("BindBuffer")
+}
+
+func BindBufferBase(target uint32, index uint32, buffer uint32) {
+	impl.BindBufferBase(target, index, buffer)
+	check("BindBufferBase")
+}
+
+func BindFramebuffer(target uint32, framebuffer uint32) {
+	impl.BindFramebuffer(target, framebuffer)
+	check("BindFramebuffer")
+}
+
+func BindRenderbuffer(target uint32, renderbuffer uint32) {
+	impl.BindRenderbuffer(target, renderbuffer)
+	check("BindRenderbuffer")
+}
+
+func BindTexture(target uint32, texture uint32) {
+	impl.BindTexture(target, texture)
+	check("BindTexture")
+}
+
+func BindVertexArray(array uint32) {
+	impl.BindVertexArray(array)
+	check("BindVertexArray")
+}
+
+func BlitFramebuffer(srcX0 int32, srcY0 int32, srcX1 int32, srcY1 int32, dstX0 int32, dstY0 int32, dstX1 int32, dstY1 int32, mask uint32, filter uint32) {
+	impl.BlitFramebuffer(srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1, mask, filter)
+	check("BlitFramebuffer")
+}
+
+func BufferData(target uint32, size int, data unsafe.Pointer, usage uint32) {
+	impl.BufferData(target, size, data, usage)
+	check("BufferData")
+}
+
+func BufferSubData(target uint32, offset int, size int, data unsafe.Pointer) {
+	impl.BufferSubData(target, offset, size, data)
+	check("BufferSubData")
+}
+
+func CheckFramebufferStatus(target uint32) uint32 {
+	result := impl.CheckFramebufferStatus(target)
+	check("CheckFramebufferStatus")
+	return result
+}
+
+func Clear(mask uint32) {
+	impl.Clear(mask)
+	check("Clear")
+}
+
+func ClearColor(red float32, green float32, blue float32, alpha float32) {
+	impl.ClearColor(red, green, blue, alpha)
+	check("ClearColor")
+}
+
+func CompileShader(shader uint32) {
+	impl.CompileShader(shader)
+	check("CompileShader")
+}
+
+func CreateProgram() uint32 {
+	result := impl.CreateProgram()
+	check("CreateProgram")
+	return result
+}
+
+func CreateShader(xtype uint32) uint32 {
+	result := impl.CreateShader(xtype)
+	check("CreateShader")
+	return result
+}
+
+func DebugMessageCallback(callback DebugProc, userParam unsafe.Pointer) {
+	impl.DebugMessageCallback(callback, userParam)
+	check("DebugMessageCallback")
+}
+
+func DebugMessageCallbackARB(callback DebugProc, userParam unsafe.Pointer) {
+	impl.DebugMessageCallbackARB(callback, userParam)
+	check("DebugMessageCallbackARB")
+}
+
+func DebugMessageControl(source uint32, xtype uint32, severity uint32, count int32, ids *uint32, enabled bool) {
+	impl.DebugMessageControl(source, xtype, severity, count, ids, enabled)
+	check("DebugMessageControl")
+}
+
+func DebugMessageControlARB(source uint32, xtype uint32, severity uint32, count int32, ids *uint32, enabled bool) {
+	impl.DebugMessageControlARB(source, xtype, severity, count, ids, enabled)
+	check("DebugMessageControlARB")
+}
+
+func DeleteBuffers(n int32, buffers *uint32) {
+	impl.DeleteBuffers(n, buffers)
+	check("DeleteBuffers")
+}
+
+func DeleteFramebuffers(n int32, framebuffers *uint32) {
+	impl.DeleteFramebuffers(n, framebuffers)
+	check("DeleteFramebuffers")
+}
+
+func DeleteProgram(program uint32) {
+	impl.DeleteProgram(program)
+	check("DeleteProgram")
+}
+
+func DeleteRenderbuffers(n int32, renderbuffers *uint32) {
+	impl.DeleteRenderbuffers(n, renderbuffers)
+	check("DeleteRenderbuffers")
+}
+
+func DeleteShader(shader uint32) {
+	impl.DeleteShader(shader)
+	check("DeleteShader")
+}
+
+func DeleteTextures(n int32, textures *uint32) {
+	impl.DeleteTextures(n, textures)
+	check("DeleteTextures")
+}
+
+func DeleteVertexArrays(n int32, arrays *uint32) {
+	impl.DeleteVertexArrays(n, arrays)
+	check("DeleteVertexArrays")
+}
+
+func DepthFunc(xfunc uint32) {
+	impl.DepthFunc(xfunc)
+	check("DepthFunc")
+}
+
+func Disable(cap uint32) {
+	impl.Disable(cap)
+	check("Disable")
+}
+
+func DisableVertexAttribArray(index uint32) {
+	impl.DisableVertexAttribArray(index)
+	check("DisableVertexAttribArray")
+}
+
+func DrawArrays(mode uint32, first int32, count int32) {
+	impl.DrawArrays(mode, first, count)
+	check("DrawArrays")
+}
+
+func Enable(cap uint32) {
+	impl.Enable(cap)
+	check("Enable")
+}
+
+func EnableVertexAttribArray(index uint32) {
+	impl.EnableVertexAttribArray(index)
+	check("EnableVertexAttribArray")
+}
+
+func FramebufferRenderbuffer(target uint32, attachment uint32, renderbuffertarget uint32, renderbuffer uint32) {
+	impl.FramebufferRenderbuffer(target, attachment, renderbuffertarget, renderbuffer)
+	check("FramebufferRenderbuffer")
+}
+
+func GenBuffers(n int32, buffers *uint32) {
+	impl.GenBuffers(n, buffers)
+	check("GenBuffers")
+}
+
+func GenFramebuffers(n int32, framebuffers *uint32) {
+	impl.GenFramebuffers(n, framebuffers)
+	check("GenFramebuffers")
+}
+
+func GenRenderbuffers(n int32, renderbuffers *uint32) {
+	impl.GenRenderbuffers(n, renderbuffers)
+	check("GenRenderbuffers")
+}
+
+func GenTextures(n int32, textures *uint32) {
+	impl.GenTextures(n, textures)
+	check("GenTextures")
+}
+
+func GenVertexArrays(n int32, arrays *uint32) {
+	impl.GenVertexArrays(n, arrays)
+	check("GenVertexArrays")
+}
+
+func GetActiveUniformBlockiv(program uint32, uniformBlockIndex uint32, pname uint32, params *int32) {
+	impl.GetActiveUniformBlockiv(program, uniformBlockIndex, pname, params)
+	check("GetActiveUniformBlockiv")
+}
+
+func GetAttribLocation(program uint32, name *uint8) int32 {
+	result := impl.GetAttribLocation(program, name)
+	check("GetAttribLocation")
+	return result
+}
+
+func GetIntegerv(pname uint32, data *int32) {
+	impl.GetIntegerv(pname, data)
+	check("GetIntegerv")
+}
+
+func GetProgramBinary(program uint32, bufSize int32, length *int32, binaryFormat *uint32, binary unsafe.Pointer) {
+	impl.GetProgramBinary(program, bufSize, length, binaryFormat, binary)
+	check("GetProgramBinary")
+}
+
+func GetProgramInfoLog(program uint32, bufSize int32, length *int32, infoLog *uint8) {
+	impl.GetProgramInfoLog(program, bufSize, length, infoLog)
+	check("GetProgramInfoLog")
+}
+
+func GetProgramiv(program uint32, pname uint32, params *int32) {
+	impl.GetProgramiv(program, pname, params)
+	check("GetProgramiv")
+}
+
+func GetShaderInfoLog(shader uint32, bufSize int32, length *int32, infoLog *uint8) {
+	impl.GetShaderInfoLog(shader, bufSize, length, infoLog)
+	check("GetShaderInfoLog")
+}
+
+func GetShaderiv(shader uint32, pname uint32, params *int32) {
+	impl.GetShaderiv(shader, pname, params)
+	check("GetShaderiv")
+}
+
+func GetString(name uint32) *uint8 {
+	result := impl.GetString(name)
+	check("GetString")
+	return result
+}
+
+func GetUniformBlockIndex(program uint32, uniformBlockName *uint8) uint32 {
+	result := impl.GetUniformBlockIndex(program, uniformBlockName)
+	check("GetUniformBlockIndex")
+	return result
+}
+
+func GetUniformLocation(program uint32, name *uint8) int32 {
+	result := impl.GetUniformLocation(program, name)
+	check("GetUniformLocation")
+	return result
+}
+
+func LinkProgram(program uint32) {
+	impl.LinkProgram(program)
+	check("LinkProgram")
+}
+
+func PixelStorei(pname uint32, param int32) {
+	impl.PixelStorei(pname, param)
+	check("PixelStorei")
+}
+
+func PolygonMode(face uint32, mode uint32) {
+	impl.PolygonMode(face, mode)
+	check("PolygonMode")
+}
+
+func ProgramBinary(program uint32, binaryFormat uint32, binary unsafe.Pointer, length int32) {
+	impl.ProgramBinary(program, binaryFormat, binary, length)
+	check("ProgramBinary")
+}
+
+func ProgramParameteri(program uint32, pname uint32, value int32) {
+	impl.ProgramParameteri(program, pname, value)
+	check("ProgramParameteri")
+}
+
+func ReadPixels(x int32, y int32, width int32, height int32, format uint32, xtype uint32, pixels unsafe.Pointer) {
+	impl.ReadPixels(x, y, width, height, format, xtype, pixels)
+	check("ReadPixels")
+}
+
+func RenderbufferStorage(target uint32, internalformat uint32, width int32, height int32) {
+	impl.RenderbufferStorage(target, internalformat, width, height)
+	check("RenderbufferStorage")
+}
+
+func RenderbufferStorageMultisample(target uint32, samples int32, internalformat uint32, width int32, height int32) {
+	impl.RenderbufferStorageMultisample(target, samples, internalformat, width, height)
+	check("RenderbufferStorageMultisample")
+}
+
+func ShaderSource(shader uint32, count int32, xstring **uint8, length *int32) {
+	impl.ShaderSource(shader, count, xstring, length)
+	check("ShaderSource")
+}
+
+func TexImage2D(target uint32, level int32, internalformat int32, width int32, height int32, border int32, format uint32, xtype uint32, pixels unsafe.Pointer) {
+	impl.TexImage2D(target, level, internalformat, width, height, border, format, xtype, pixels)
+	check("TexImage2D")
+}
+
+func TexParameteri(target uint32, pname uint32, param int32) {
+	impl.TexParameteri(target, pname, param)
+	check("TexParameteri")
+}
+
+func Uniform1f(location int32, v0 float32) {
+	impl.Uniform1f(location, v0)
+	check("Uniform1f")
+}
+
+func Uniform1i(location int32, v0 int32) {
+	impl.Uniform1i(location, v0)
+	check("Uniform1i")
+}
+
+func Uniform3fv(location int32, count int32, value *float32) {
+	impl.Uniform3fv(location, count, value)
+	check("Uniform3fv")
+}
+
+func Uniform4fv(location int32, count int32, value *float32) {
+	impl.Uniform4fv(location, count, value)
+	check("Uniform4fv")
+}
+
+func UniformBlockBinding(program uint32, uniformBlockIndex uint32, uniformBlockBinding uint32) {
+	impl.UniformBlockBinding(program, uniformBlockIndex, uniformBlockBinding)
+	check("UniformBlockBinding")
+}
+
+func UniformMatrix4fv(location int32, count int32, transpose bool, value *float32) {
+	impl.UniformMatrix4fv(location, count, transpose, value)
+	check("UniformMatrix4fv")
+}
+
+func UseProgram(program uint32) {
+	impl.UseProgram(program)
+	check("UseProgram")
+}
+
+func VertexAttribPointer(index uint32, size int32, xtype uint32, normalized bool, stride int32, pointer unsafe.Pointer) {
+	impl.VertexAttribPointer(index, size, xtype, normalized, stride, pointer)
+	check("VertexAttribPointer")
+}
+
+func Viewport(x int32, y int32, width int32, height int32) {
+	impl.Viewport(x, y, width, height)
+	check("Viewport")
+}
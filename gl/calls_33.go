@@ -0,0 +1,83 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+//go:build gl33 && !gldebug
+
+package gl
+
+import impl "github.com/go-gl/gl/v3.3-core/gl"
+
+var (
+	ActiveTexture                  = impl.ActiveTexture
+	AttachShader                   = impl.AttachShader
+	BindAttribLocation             = impl.BindAttribLocation
+	BindBuffer                     = impl.BindBuffer
+	BindBufferBase                 = impl.BindBufferBase
+	BindFramebuffer                = impl.BindFramebuffer
+	BindRenderbuffer               = impl.BindRenderbuffer
+	BindTexture                    = impl.BindTexture
+	BindVertexArray                = impl.BindVertexArray
+	BlitFramebuffer                = impl.BlitFramebuffer
+	BufferData                     = impl.BufferData
+	BufferSubData                  = impl.BufferSubData
+	CheckFramebufferStatus         = impl.CheckFramebufferStatus
+	Clear                          = impl.Clear
+	ClearColor                     = impl.ClearColor
+	CompileShader                  = impl.CompileShader
+	CreateProgram                  = impl.CreateProgram
+	CreateShader                   = impl.CreateShader
+	DebugMessageCallback           = impl.DebugMessageCallback
+	DebugMessageCallbackARB        = impl.DebugMessageCallbackARB
+	DebugMessageControl            = impl.DebugMessageControl
+	DebugMessageControlARB         = impl.DebugMessageControlARB
+	DeleteBuffers                  = impl.DeleteBuffers
+	DeleteFramebuffers             = impl.DeleteFramebuffers
+	DeleteProgram                  = impl.DeleteProgram
+	DeleteRenderbuffers            = impl.DeleteRenderbuffers
+	DeleteShader                   = impl.DeleteShader
+	DeleteTextures                 = impl.DeleteTextures
+	DeleteVertexArrays             = impl.DeleteVertexArrays
+	DepthFunc                      = impl.DepthFunc
+	Disable                        = impl.Disable
+	DisableVertexAttribArray       = impl.DisableVertexAttribArray
+	DrawArrays                     = impl.DrawArrays
+	Enable                         = impl.Enable
+	EnableVertexAttribArray        = impl.EnableVertexAttribArray
+	FramebufferRenderbuffer        = impl.FramebufferRenderbuffer
+	GenBuffers                     = impl.GenBuffers
+	GenFramebuffers                = impl.GenFramebuffers
+	GenRenderbuffers               = impl.GenRenderbuffers
+	GenTextures                    = impl.GenTextures
+	GenVertexArrays                = impl.GenVertexArrays
+	GetActiveUniformBlockiv        = impl.GetActiveUniformBlockiv
+	GetAttribLocation              = impl.GetAttribLocation
+	GetIntegerv                    = impl.GetIntegerv
+	GetProgramBinary               = impl.GetProgramBinary
+	GetProgramInfoLog              = impl.GetProgramInfoLog
+	GetProgramiv                   = impl.GetProgramiv
+	GetShaderInfoLog               = impl.GetShaderInfoLog
+	GetShaderiv                    = impl.GetShaderiv
+	GetString                      = impl.GetString
+	GetStringi                     = impl.GetStringi
+	GetUniformBlockIndex           = impl.GetUniformBlockIndex
+	GetUniformLocation             = impl.GetUniformLocation
+	LinkProgram                    = impl.LinkProgram
+	PixelStorei                    = impl.PixelStorei
+	PolygonMode                    = impl.PolygonMode
+	ProgramBinary                  = impl.ProgramBinary
+	ProgramParameteri              = impl.ProgramParameteri
+	ReadPixels                     = impl.ReadPixels
+	RenderbufferStorage            = impl.RenderbufferStorage
+	RenderbufferStorageMultisample = impl.RenderbufferStorageMultisample
+	ShaderSource                   = impl.ShaderSource
+	TexImage2D                     = impl.TexImage2D
+	TexParameteri                  = impl.TexParameteri
+	Uniform1f                      = impl.Uniform1f
+	Uniform1i                      = impl.Uniform1i
+	Uniform3fv                     = impl.Uniform3fv
+	Uniform4fv                     = impl.Uniform4fv
+	UniformBlockBinding            = impl.UniformBlockBinding
+	UniformMatrix4fv               = impl.UniformMatrix4fv
+	UseProgram                     = impl.UseProgram
+	VertexAttribPointer            = impl.VertexAttribPointer
+	Viewport                       = impl.Viewport
+)
@@ -0,0 +1,33 @@
+//go:build gldebug
+
+package gl
+
+import (
+	"log"
+	"runtime"
+)
+
+// maxPendingErrors - Most errors reported after one call. Without a current context GetError
+// keeps returning an error, so draining has to stop somewhere.
+const maxPendingErrors = 16
+
+// check - Reports the errors pending after call together with the code that made the call
+func check(call string) {
+	for i := 0; ; i++ {
+		code := GetError()
+		if code == NO_ERROR {
+			return
+		}
+
+		// Skip check and the generated wrapper
+		_, file, line, ok := runtime.Caller(2)
+		if !ok {
+			file, line = "unknown", 0
+		}
+		if i == maxPendingErrors {
+			log.Printf("gl.%s: more than %d errors at %s:%d, the rest are not reported", call, maxPendingErrors, file, line)
+			return
+		}
+		log.Printf("gl.%s: %s at %s:%d", call, ErrorString(code), file, line)
+	}
+}
@@ -0,0 +1,15 @@
+// Package gl re-exports the go-gl OpenGL binding selected by build tags, so the helpers and
+// the demos always share one binding and one set of loaded function pointers.
+//
+//	(default)   github.com/go-gl/gl/v4.1-core/gl
+//	-tags gl33  github.com/go-gl/gl/v3.3-core/gl
+//	-tags gl21  github.com/go-gl/gl/v2.1/gl
+//
+// Adding -tags gldebug to any of these wraps every GL function so glGetError is checked after
+// each call and failures are logged with the file and line that made the call.
+//
+// The exposed symbols are listed in gen.go. Add to the lists there and run go generate
+// to make more of the API available.
+package gl
+
+//go:generate go run gen.go
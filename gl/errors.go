@@ -0,0 +1,26 @@
+package gl
+
+import "fmt"
+
+// ErrorString - Returns the name of a glGetError code
+func ErrorString(code uint32) string {
+	switch code {
+	case NO_ERROR:
+		return "GL_NO_ERROR"
+	case INVALID_ENUM:
+		return "GL_INVALID_ENUM"
+	case INVALID_VALUE:
+		return "GL_INVALID_VALUE"
+	case INVALID_OPERATION:
+		return "GL_INVALID_OPERATION"
+	case INVALID_FRAMEBUFFER_OPERATION:
+		return "GL_INVALID_FRAMEBUFFER_OPERATION"
+	case OUT_OF_MEMORY:
+		return "GL_OUT_OF_MEMORY"
+	case STACK_OVERFLOW:
+		return "GL_STACK_OVERFLOW"
+	case STACK_UNDERFLOW:
+		return "GL_STACK_UNDERFLOW"
+	}
+	return fmt.Sprintf("0x%x", code)
+}
@@ -0,0 +1,391 @@
+//go:build ignore
+// +build ignore
+
+// Generates the files that re-export the go-gl binding for each build tag: profile_*.go with the
+// types, constants and loader helpers, and calls_*.go with the GL functions. With the gldebug tag
+// calls_*_gldebug.go replaces the latter, wrapping every function so glGetError is checked after it.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type profile struct {
+	suffix     string
+	constraint string
+	importPath string
+	name       string
+
+	// missing lists symbols the binding lacks; code using them needs its own build tags
+	missing []string
+}
+
+var profiles = []profile{
+	{"41", "!gl21 && !gl33", "github.com/go-gl/gl/v4.1-core/gl", "4.1-core", nil},
+	{"33", "gl33", "github.com/go-gl/gl/v3.3-core/gl", "3.3-core", nil},
+	{"21", "gl21", "github.com/go-gl/gl/v2.1/gl", "2.1", []string{"GetStringi", "NUM_EXTENSIONS"}},
+}
+
+func (p profile) lacks(name string) bool {
+	for _, missing := range p.missing {
+		if missing == name {
+			return true
+		}
+	}
+	return false
+}
+
+var types = []string{
+	"DebugProc",
+}
+
+// utilities are plain Go functions of the binding, never wrapped
+var utilities = []string{
+	"Init",
+	"InitWithProcAddrFunc",
+	"GoStr",
+	"Ptr",
+	"PtrOffset",
+	"Str",
+	"Strs",
+}
+
+// unchecked are GL functions that must not be followed by a glGetError check
+var unchecked = []string{
+	"GetError",
+}
+
+var functions = []string{
+	// State
+	"Clear",
+	"ClearColor",
+	"DepthFunc",
+	"Disable",
+	"Enable",
+	"GetIntegerv",
+	"GetString",
+	"GetStringi",
+	"PolygonMode",
+	"Viewport",
+
+	// Buffers and vertex arrays
+	"BindBuffer",
+	"BindBufferBase",
+	"BindVertexArray",
+	"BufferData",
+	"BufferSubData",
+	"DeleteBuffers",
+	"DeleteVertexArrays",
+	"DisableVertexAttribArray",
+	"DrawArrays",
+	"EnableVertexAttribArray",
+	"GenBuffers",
+	"GenVertexArrays",
+	"VertexAttribPointer",
+
+	// Shaders and programs
+	"AttachShader",
+	"BindAttribLocation",
+	"CompileShader",
+	"CreateProgram",
+	"CreateShader",
+	"DeleteProgram",
+	"DeleteShader",
+	"GetActiveUniformBlockiv",
+	"GetAttribLocation",
+	"GetProgramBinary",
+	"GetProgramInfoLog",
+	"GetProgramiv",
+	"GetShaderInfoLog",
+	"GetShaderiv",
+	"GetUniformBlockIndex",
+	"GetUniformLocation",
+	"LinkProgram",
+	"ProgramBinary",
+	"ProgramParameteri",
+	"ShaderSource",
+	"Uniform1f",
+	"Uniform1i",
+	"Uniform3fv",
+	"Uniform4fv",
+	"UniformBlockBinding",
+	"UniformMatrix4fv",
+	"UseProgram",
+
+	// Textures
+	"ActiveTexture",
+	"BindTexture",
+	"DeleteTextures",
+	"GenTextures",
+	"TexImage2D",
+	"TexParameteri",
+
+	// Framebuffers
+	"BindFramebuffer",
+	"BindRenderbuffer",
+	"BlitFramebuffer",
+	"CheckFramebufferStatus",
+	"DeleteFramebuffers",
+	"DeleteRenderbuffers",
+	"FramebufferRenderbuffer",
+	"GenFramebuffers",
+	"GenRenderbuffers",
+	"PixelStorei",
+	"ReadPixels",
+	"RenderbufferStorage",
+	"RenderbufferStorageMultisample",
+
+	// Debug output
+	"DebugMessageCallback",
+	"DebugMessageCallbackARB",
+	"DebugMessageControl",
+	"DebugMessageControlARB",
+}
+
+var constants = []string{
+	"ARRAY_BUFFER",
+	"CLAMP_TO_EDGE",
+	"COLOR_ATTACHMENT0",
+	"COLOR_BUFFER_BIT",
+	"COMPILE_STATUS",
+	"CULL_FACE",
+	"DEBUG_OUTPUT",
+	"DEBUG_OUTPUT_SYNCHRONOUS",
+	"DEBUG_SEVERITY_HIGH",
+	"DEBUG_SEVERITY_LOW",
+	"DEBUG_SEVERITY_MEDIUM",
+	"DEBUG_SEVERITY_NOTIFICATION",
+	"DEBUG_SOURCE_API",
+	"DEBUG_SOURCE_APPLICATION",
+	"DEBUG_SOURCE_OTHER",
+	"DEBUG_SOURCE_SHADER_COMPILER",
+	"DEBUG_SOURCE_THIRD_PARTY",
+	"DEBUG_SOURCE_WINDOW_SYSTEM",
+	"DEBUG_TYPE_DEPRECATED_BEHAVIOR",
+	"DEBUG_TYPE_ERROR",
+	"DEBUG_TYPE_MARKER",
+	"DEBUG_TYPE_OTHER",
+	"DEBUG_TYPE_PERFORMANCE",
+	"DEBUG_TYPE_POP_GROUP",
+	"DEBUG_TYPE_PORTABILITY",
+	"DEBUG_TYPE_PUSH_GROUP",
+	"DEBUG_TYPE_UNDEFINED_BEHAVIOR",
+	"DEPTH24_STENCIL8",
+	"DEPTH_BUFFER_BIT",
+	"DEPTH_STENCIL_ATTACHMENT",
+	"DEPTH_TEST",
+	"DONT_CARE",
+	"DRAW_FRAMEBUFFER",
+	"DYNAMIC_DRAW",
+	"EXTENSIONS",
+	"FALSE",
+	"FILL",
+	"FLOAT",
+	"FRAGMENT_SHADER",
+	"FRONT_AND_BACK",
+	"FRAMEBUFFER",
+	"FRAMEBUFFER_COMPLETE",
+	"INFO_LOG_LENGTH",
+	"INVALID_ENUM",
+	"INVALID_FRAMEBUFFER_OPERATION",
+	"INVALID_INDEX",
+	"INVALID_OPERATION",
+	"INVALID_VALUE",
+	"LESS",
+	"LINE",
+	"LINEAR",
+	"LINK_STATUS",
+	"MAX_RENDERBUFFER_SIZE",
+	"MAX_VIEWPORT_DIMS",
+	"NEAREST",
+	"NO_ERROR",
+	"NUM_EXTENSIONS",
+	"NUM_PROGRAM_BINARY_FORMATS",
+	"OUT_OF_MEMORY",
+	"PACK_ALIGNMENT",
+	"PROGRAM_BINARY_LENGTH",
+	"PROGRAM_BINARY_RETRIEVABLE_HINT",
+	"READ_FRAMEBUFFER",
+	"RENDERBUFFER",
+	"RENDERER",
+	"RGBA",
+	"RGBA8",
+	"SHADING_LANGUAGE_VERSION",
+	"STACK_OVERFLOW",
+	"STACK_UNDERFLOW",
+	"STATIC_DRAW",
+	"TEXTURE0",
+	"TEXTURE_2D",
+	"TEXTURE_MAG_FILTER",
+	"TEXTURE_MIN_FILTER",
+	"TEXTURE_WRAP_S",
+	"TEXTURE_WRAP_T",
+	"TRIANGLES",
+	"TRUE",
+	"UNIFORM_BLOCK_DATA_SIZE",
+	"UNIFORM_BUFFER",
+	"UNSIGNED_BYTE",
+	"VENDOR",
+	"VERSION",
+	"VERTEX_SHADER",
+}
+
+func main() {
+	sort.Strings(types)
+	sort.Strings(utilities)
+	sort.Strings(unchecked)
+	sort.Strings(functions)
+	sort.Strings(constants)
+
+	for _, p := range profiles {
+		write("profile_"+p.suffix+".go", p.profile())
+		write("calls_"+p.suffix+".go", p.calls())
+		write("calls_"+p.suffix+"_gldebug.go", p.checkedCalls())
+	}
+}
+
+func (p profile) header(b *bytes.Buffer, constraint string) {
+	fmt.Fprintf(b, "// Code generated by gen.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(b, "//go:build %s\n\n", constraint)
+	fmt.Fprintf(b, "package gl\n\n")
+}
+
+// profile - Types, constants and the loader, which are the same with and without gldebug
+func (p profile) profile() []byte {
+	var b bytes.Buffer
+	p.header(&b, p.constraint)
+	fmt.Fprintf(&b, "import impl %q\n\n", p.importPath)
+	fmt.Fprintf(&b, "// Profile - The OpenGL binding this package was built against\n")
+	fmt.Fprintf(&b, "const Profile = %q\n\n", p.name)
+	for _, name := range types {
+		if p.lacks(name) {
+			continue
+		}
+		fmt.Fprintf(&b, "type %s = impl.%s\n", name, name)
+	}
+	fmt.Fprintf(&b, "\nconst (\n")
+	for _, name := range constants {
+		if p.lacks(name) {
+			continue
+		}
+		fmt.Fprintf(&b, "\t%s = impl.%s\n", name, name)
+	}
+	fmt.Fprintf(&b, ")\n\nvar (\n")
+	for _, name := range append(append([]string{}, utilities...), unchecked...) {
+		if p.lacks(name) {
+			continue
+		}
+		fmt.Fprintf(&b, "\t%s = impl.%s\n", name, name)
+	}
+	fmt.Fprintf(&b, ")\n")
+	return b.Bytes()
+}
+
+// calls - The GL functions, used as they are
+func (p profile) calls() []byte {
+	var b bytes.Buffer
+	p.header(&b, "("+p.constraint+") && !gldebug")
+	fmt.Fprintf(&b, "import impl %q\n\n", p.importPath)
+	fmt.Fprintf(&b, "var (\n")
+	for _, name := range functions {
+		if p.lacks(name) {
+			continue
+		}
+		fmt.Fprintf(&b, "\t%s = impl.%s\n", name, name)
+	}
+	fmt.Fprintf(&b, ")\n")
+	return b.Bytes()
+}
+
+// checkedCalls - The GL functions, each followed by a check of glGetError
+func (p profile) checkedCalls() []byte {
+	decls := p.declarations()
+
+	var body bytes.Buffer
+	for _, name := range functions {
+		if p.lacks(name) {
+			continue
+		}
+		decl, ok := decls[name]
+		if !ok {
+			log.Fatalf("%s: no function %s", p.importPath, name)
+		}
+
+		var params, args []string
+		for _, field := range decl.Type.Params.List {
+			for _, ident := range field.Names {
+				params = append(params, ident.Name+" "+node(field.Type))
+				args = append(args, ident.Name)
+			}
+		}
+		call := fmt.Sprintf("impl.%s(%s)", name, strings.Join(args, ", "))
+		signature := fmt.Sprintf("func %s(%s)", name, strings.Join(params, ", "))
+
+		if decl.Type.Results == nil {
+			fmt.Fprintf(&body, "%s {\n\t%s\n\tcheck(%q)\n}\n\n", signature, call, name)
+			continue
+		}
+		result := node(decl.Type.Results.List[0].Type)
+		fmt.Fprintf(&body, "%s %s {\n\tresult := %s\n\tcheck(%q)\n\treturn result\n}\n\n", signature, result, call, name)
+	}
+
+	var b bytes.Buffer
+	p.header(&b, "("+p.constraint+") && gldebug")
+	fmt.Fprintf(&b, "import (\n")
+	if strings.Contains(body.String(), "unsafe.") {
+		fmt.Fprintf(&b, "\t\"unsafe\"\n\n")
+	}
+	fmt.Fprintf(&b, "\timpl %q\n)\n\n", p.importPath)
+	b.Write(body.Bytes())
+	return b.Bytes()
+}
+
+// declarations - Parses the binding's source for the signatures of its functions
+func (p profile) declarations() map[string]*ast.FuncDecl {
+	dir, err := exec.Command("go", "list", "-f", "{{.Dir}}", p.importPath).Output()
+	if err != nil {
+		log.Fatalf("failed to locate %s: %v", p.importPath, err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filepath.Join(strings.TrimSpace(string(dir)), "package.go"), nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	decls := make(map[string]*ast.FuncDecl)
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+			decls[fn.Name.Name] = fn
+		}
+	}
+	return decls
+}
+
+func node(n ast.Node) string {
+	var b bytes.Buffer
+	printer.Fprint(&b, token.NewFileSet(), n)
+	return b.String()
+}
+
+func write(file string, b []byte) {
+	source, err := format.Source(b)
+	if err != nil {
+		log.Fatalf("%s: %v", file, err)
+	}
+	if err := ioutil.WriteFile(file, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
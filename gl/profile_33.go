@@ -0,0 +1,111 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+//go:build gl33
+
+package gl
+
+import impl "github.com/go-gl/gl/v3.3-core/gl"
+
+// Profile - The OpenGL binding this package was built against
+const Profile = "3.3-core"
+
+type DebugProc = impl.DebugProc
+
+const (
+	ARRAY_BUFFER                    = impl.ARRAY_BUFFER
+	CLAMP_TO_EDGE                   = impl.CLAMP_TO_EDGE
+	COLOR_ATTACHMENT0               = impl.COLOR_ATTACHMENT0
+	COLOR_BUFFER_BIT                = impl.COLOR_BUFFER_BIT
+	COMPILE_STATUS                  = impl.COMPILE_STATUS
+	CULL_FACE                       = impl.CULL_FACE
+	DEBUG_OUTPUT                    = impl.DEBUG_OUTPUT
+	DEBUG_OUTPUT_SYNCHRONOUS        = impl.DEBUG_OUTPUT_SYNCHRONOUS
+	DEBUG_SEVERITY_HIGH             = impl.DEBUG_SEVERITY_HIGH
+	DEBUG_SEVERITY_LOW              = impl.DEBUG_SEVERITY_LOW
+	DEBUG_SEVERITY_MEDIUM           = impl.DEBUG_SEVERITY_MEDIUM
+	DEBUG_SEVERITY_NOTIFICATION     = impl.DEBUG_SEVERITY_NOTIFICATION
+	DEBUG_SOURCE_API                = impl.DEBUG_SOURCE_API
+	DEBUG_SOURCE_APPLICATION        = impl.DEBUG_SOURCE_APPLICATION
+	DEBUG_SOURCE_OTHER              = impl.DEBUG_SOURCE_OTHER
+	DEBUG_SOURCE_SHADER_COMPILER    = impl.DEBUG_SOURCE_SHADER_COMPILER
+	DEBUG_SOURCE_THIRD_PARTY        = impl.DEBUG_SOURCE_THIRD_PARTY
+	DEBUG_SOURCE_WINDOW_SYSTEM      = impl.DEBUG_SOURCE_WINDOW_SYSTEM
+	DEBUG_TYPE_DEPRECATED_BEHAVIOR  = impl.DEBUG_TYPE_DEPRECATED_BEHAVIOR
+	DEBUG_TYPE_ERROR                = impl.DEBUG_TYPE_ERROR
+	DEBUG_TYPE_MARKER               = impl.DEBUG_TYPE_MARKER
+	DEBUG_TYPE_OTHER                = impl.DEBUG_TYPE_OTHER
+	DEBUG_TYPE_PERFORMANCE          = impl.DEBUG_TYPE_PERFORMANCE
+	DEBUG_TYPE_POP_GROUP            = impl.DEBUG_TYPE_POP_GROUP
+	DEBUG_TYPE_PORTABILITY          = impl.DEBUG_TYPE_PORTABILITY
+	DEBUG_TYPE_PUSH_GROUP           = impl.DEBUG_TYPE_PUSH_GROUP
+	DEBUG_TYPE_UNDEFINED_BEHAVIOR   = impl.DEBUG_TYPE_UNDEFINED_BEHAVIOR
+	DEPTH24_STENCIL8                = impl.DEPTH24_STENCIL8
+	DEPTH_BUFFER_BIT                = impl.DEPTH_BUFFER_BIT
+	DEPTH_STENCIL_ATTACHMENT        = impl.DEPTH_STENCIL_ATTACHMENT
+	DEPTH_TEST                      = impl.DEPTH_TEST
+	DONT_CARE                       = impl.DONT_CARE
+	DRAW_FRAMEBUFFER                = impl.DRAW_FRAMEBUFFER
+	DYNAMIC_DRAW                    = impl.DYNAMIC_DRAW
+	EXTENSIONS                      = impl.EXTENSIONS
+	FALSE                           = impl.FALSE
+	FILL                            = impl.FILL
+	FLOAT                           = impl.FLOAT
+	FRAGMENT_SHADER                 = impl.FRAGMENT_SHADER
+	FRAMEBUFFER                     = impl.FRAMEBUFFER
+	FRAMEBUFFER_COMPLETE            = impl.FRAMEBUFFER_COMPLETE
+	FRONT_AND_BACK                  = impl.FRONT_AND_BACK
+	INFO_LOG_LENGTH                 = impl.INFO_LOG_LENGTH
+	INVALID_ENUM                    = impl.INVALID_ENUM
+	INVALID_FRAMEBUFFER_OPERATION   = impl.INVALID_FRAMEBUFFER_OPERATION
+	INVALID_INDEX                   = impl.INVALID_INDEX
+	INVALID_OPERATION               = impl.INVALID_OPERATION
+	INVALID_VALUE                   = impl.INVALID_VALUE
+	LESS                            = impl.LESS
+	LINE                            = impl.LINE
+	LINEAR                          = impl.LINEAR
+	LINK_STATUS                     = impl.LINK_STATUS
+	MAX_RENDERBUFFER_SIZE           = impl.MAX_RENDERBUFFER_SIZE
+	MAX_VIEWPORT_DIMS               = impl.MAX_VIEWPORT_DIMS
+	NEAREST                         = impl.NEAREST
+	NO_ERROR                        = impl.NO_ERROR
+	NUM_EXTENSIONS                  = impl.NUM_EXTENSIONS
+	NUM_PROGRAM_BINARY_FORMATS      = impl.NUM_PROGRAM_BINARY_FORMATS
+	OUT_OF_MEMORY                   = impl.OUT_OF_MEMORY
+	PACK_ALIGNMENT                  = impl.PACK_ALIGNMENT
+	PROGRAM_BINARY_LENGTH           = impl.PROGRAM_BINARY_LENGTH
+	PROGRAM_BINARY_RETRIEVABLE_HINT = impl.PROGRAM_BINARY_RETRIEVABLE_HINT
+	READ_FRAMEBUFFER                = impl.READ_FRAMEBUFFER
+	RENDERBUFFER                    = impl.RENDERBUFFER
+	RENDERER                        = impl.RENDERER
+	RGBA                            = impl.RGBA
+	RGBA8                           = impl.RGBA8
+	SHADING_LANGUAGE_VERSION        = impl.SHADING_LANGUAGE_VERSION
+	STACK_OVERFLOW                  = impl.STACK_OVERFLOW
+	STACK_UNDERFLOW                 = impl.STACK_UNDERFLOW
+	STATIC_DRAW                     = impl.STATIC_DRAW
+	TEXTURE0                        = impl.TEXTURE0
+	TEXTURE_2D                      = impl.TEXTURE_2D
+	TEXTURE_MAG_FILTER              = impl.TEXTURE_MAG_FILTER
+	TEXTURE_MIN_FILTER              = impl.TEXTURE_MIN_FILTER
+	TEXTURE_WRAP_S                  = impl.TEXTURE_WRAP_S
+	TEXTURE_WRAP_T                  = impl.TEXTURE_WRAP_T
+	TRIANGLES                       = impl.TRIANGLES
+	TRUE                            = impl.TRUE
+	UNIFORM_BLOCK_DATA_SIZE         = impl.UNIFORM_BLOCK_DATA_SIZE
+	UNIFORM_BUFFER                  = impl.UNIFORM_BUFFER
+	UNSIGNED_BYTE                   = impl.UNSIGNED_BYTE
+	VENDOR                          = impl.VENDOR
+	VERSION                         = impl.VERSION
+	VERTEX_SHADER                   = impl.VERTEX_SHADER
+)
+
+var (
+	GoStr                = impl.GoStr
+	Init                 = impl.Init
+	InitWithProcAddrFunc = impl.InitWithProcAddrFunc
+	Ptr                  = impl.Ptr
+	PtrOffset            = impl.PtrOffset
+	Str                  = impl.Str
+	Strs                 = impl.Strs
+	GetError             = impl.GetError
+)
@@ -0,0 +1,270 @@
+// Renders every demo scene offscreen at a fixed camera and time and compares the frames with
+// the reference images in golden/testdata. Run it from the repository root:
+//
+//	go run ./golden             compare, writing the actual and diff images of failures to -out
+//	go run ./golden -update     regenerate the references
+//	go run ./golden -run cube   only the scenes whose name matches the expression
+//	go run ./golden -tile 64    render in 64 pixel tiles, which have to match the same references
+//
+// Interactive scenes can be driven by an input recording in golden/testdata, made with the -record
+// flag of the testbed and replayed at the time step it was recorded with.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+
+	"github.com/thegrandpackard/gogl/app"
+	"github.com/thegrandpackard/gogl/controls"
+	"github.com/thegrandpackard/gogl/headless"
+	"github.com/thegrandpackard/gogl/helpers"
+	"github.com/thegrandpackard/gogl/scenes"
+)
+
+const width = 320
+const height = 240
+
+type testCase struct {
+	name   string
+	frames int
+	scene  func(root string) app.App
+
+	// input is a recording in golden/testdata to replay, if any
+	input string
+}
+
+var testCases = []testCase{
+	{"triangle", 1, func(root string) app.App {
+		return scenes.NewTriangle()
+	}, ""},
+	{"triangle_mvp", 1, func(root string) app.App {
+		return scenes.NewTriangleMVP(width, height)
+	}, ""},
+	{"cube_color", 1, func(root string) app.App {
+		return scenes.NewCubeColor(width, height)
+	}, ""},
+	{"cube_triangle", 1, func(root string) app.App {
+		return scenes.NewCubeTriangle(width, height)
+	}, ""},
+	{"cube", 1, func(root string) app.App {
+		return scenes.NewCube(width, height, filepath.Join(root, "cube"))
+	}, ""},
+	{"cube_textured", 1, func(root string) app.App {
+		return scenes.NewCubeTextured(width, height, filepath.Join(root, "cube_textured"))
+	}, ""},
+	{"cube_keyboard_mouse", 10, func(root string) app.App {
+		return scenes.NewCubeKeyboardMouse(width, height, filepath.Join(root, "cube_keyboard_mouse"))
+	}, ""},
+	{"cubes_rotating", 10, func(root string) app.App {
+		return scenes.NewCubesRotating(width, height, filepath.Join(root, "cubes_rotating"))
+	}, ""},
+	{"cube_keyboard_mouse_replay", 90, func(root string) app.App {
+		return scenes.NewCubeKeyboardMouse(width, height, filepath.Join(root, "cube_keyboard_mouse"))
+	}, "cube_keyboard_mouse.input"},
+	{"cubes_rotating_path", 150, func(root string) app.App {
+		return scenes.NewCubesRotating(width, height, filepath.Join(root, "cubes_rotating"))
+	}, "cubes_rotating_path.input"},
+	{"model_loading", 10, modelLoading, ""},
+	// Dragged around with the left button, then dollied in with the wheel
+	{"model_loading_orbit", 80, modelLoading, "model_loading.input"},
+}
+
+// modelLoading - The scene of the model_loading demo, the ring of dice framed by the orbit camera
+func modelLoading(root string) app.App {
+	scene := scenes.NewCubesRotating(width, height, filepath.Join(root, "cubes_rotating"))
+	scene.Controller = "orbit"
+	return scene
+}
+
+func init() {
+	// The headless context is current on the main OS thread only
+	runtime.LockOSThread()
+}
+
+func main() {
+	root := flag.String("root", ".", "repository root, used to find the demo assets")
+	update := flag.Bool("update", false, "regenerate the reference images instead of comparing against them")
+	run := flag.String("run", "", "only render scenes whose name matches this regular expression")
+	out := flag.String("out", filepath.Join("golden", "out"), "directory the actual and diff images of failures are written to")
+	tolerance := flag.Int("tolerance", 8, "largest per-channel difference for a pixel to still match")
+	threshold := flag.Float64("threshold", 0.001, "fraction of pixels allowed to exceed the tolerance")
+	samples := flag.Int("samples", 4, "MSAA samples of the offscreen framebuffer")
+	tile := flag.Int("tile", 0, "render scenes that support it in tiles of this many pixels, 0 for whole frames")
+	flag.Parse()
+
+	filter, err := regexp.Compile(*run)
+	if err != nil {
+		log.Fatalln("invalid -run expression:", err)
+	}
+	testdata := filepath.Join(*root, "golden", "testdata")
+
+	context, err := headless.NewContext(helpers.DefaultProfiles)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer context.Destroy()
+	helpers.EnableDebugOutput(helpers.DefaultDebugFilter)
+
+	failed := 0
+	for _, test := range testCases {
+		if !filter.MatchString(test.name) {
+			continue
+		}
+
+		runner := headless.NewRunner()
+		runner.Width, runner.Height = width, height
+		runner.Samples = *samples
+		runner.Frames = test.frames
+		if test.input != "" {
+			if runner.Input, runner.TimeStep, err = replay(filepath.Join(testdata, test.input)); err != nil {
+				fmt.Printf("FAIL %s: %v\n", test.name, err)
+				failed++
+				continue
+			}
+		}
+
+		scene := test.scene(*root)
+		if _, ok := scene.(interface{ SetTile(tile *helpers.Tile) }); ok {
+			runner.TileSize = *tile
+		}
+
+		got, err := runner.RunCurrent(scene)
+		if err == nil {
+			err = helpers.CheckLeaks()
+		}
+		if err != nil {
+			fmt.Printf("FAIL %s: %v\n", test.name, err)
+			failed++
+
+			// Keep the leaks of one scene from failing the next
+			helpers.ReportLeaks()
+			helpers.ForgetResources()
+			continue
+		}
+
+		reference := filepath.Join(testdata, test.name+".png")
+		if *update {
+			if err := os.MkdirAll(testdata, 0755); err != nil {
+				log.Fatalln(err)
+			}
+			if err := helpers.SavePNG(reference, got); err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Printf("updated %s\n", reference)
+			continue
+		}
+
+		if err := check(test.name, reference, got, *out, *tolerance, *threshold); err != nil {
+			fmt.Printf("FAIL %s: %v\n", test.name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("ok   %s\n", test.name)
+	}
+
+	if failed > 0 {
+		fmt.Printf("%d scene(s) failed\n", failed)
+		context.Destroy()
+		os.Exit(1)
+	}
+}
+
+// replay - A device playing back the recording in file, and the time step it was recorded with
+func replay(file string) (*controls.Device, float64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	device := controls.NewDevice(nil)
+	timeStep, err := device.Replay(f)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %v", file, err)
+	}
+	return device, timeStep, nil
+}
+
+// check - Compares got with the reference image and writes the actual and diff images to out when they differ
+func check(name, reference string, got *image.RGBA, out string, tolerance int, threshold float64) error {
+	want, err := loadPNG(reference)
+	if err != nil {
+		return fmt.Errorf("%v (run with -update to create it)", err)
+	}
+
+	fraction, diff, err := compare(want, got, tolerance)
+	if err == nil && fraction <= threshold {
+		return nil
+	}
+	if err == nil {
+		err = fmt.Errorf("%.3f%% of pixels differ by more than %d, allowed %.3f%%", fraction*100, tolerance, threshold*100)
+	}
+
+	if mkErr := os.MkdirAll(out, 0755); mkErr != nil {
+		return fmt.Errorf("%v; %v", err, mkErr)
+	}
+	if saveErr := helpers.SavePNG(filepath.Join(out, name+".actual.png"), got); saveErr != nil {
+		return fmt.Errorf("%v; %v", err, saveErr)
+	}
+	if diff != nil {
+		if saveErr := helpers.SavePNG(filepath.Join(out, name+".diff.png"), diff); saveErr != nil {
+			return fmt.Errorf("%v; %v", err, saveErr)
+		}
+	}
+	return fmt.Errorf("%v; see %s", err, out)
+}
+
+// compare - Returns the fraction of pixels with a channel differing by more than tolerance and an
+// image showing the reference dimmed with those pixels in red
+func compare(want, got *image.RGBA, tolerance int) (float64, *image.RGBA, error) {
+	if want.Bounds().Size() != got.Bounds().Size() {
+		return 1, nil, fmt.Errorf("size is %v, reference is %v", got.Bounds().Size(), want.Bounds().Size())
+	}
+
+	diff := image.NewRGBA(want.Bounds())
+	differing := 0
+	for i := 0; i < len(want.Pix); i += 4 {
+		mismatch := false
+		for c := 0; c < 4; c++ {
+			d := int(want.Pix[i+c]) - int(got.Pix[i+c])
+			if d > tolerance || -d > tolerance {
+				mismatch = true
+			}
+		}
+
+		if mismatch {
+			differing++
+			copy(diff.Pix[i:i+4], []byte{0xff, 0, 0, 0xff})
+			continue
+		}
+		grey := (int(want.Pix[i]) + int(want.Pix[i+1]) + int(want.Pix[i+2])) / 9
+		copy(diff.Pix[i:i+4], []byte{byte(grey), byte(grey), byte(grey), 0xff})
+	}
+
+	return float64(differing) / float64(len(want.Pix)/4), diff, nil
+}
+
+func loadPNG(file string) (*image.RGBA, error) {
+	in, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	img, err := png.Decode(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %q: %v", file, err)
+	}
+
+	rgba := image.NewRGBA(img.Bounds())
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	return rgba, nil
+}
@@ -0,0 +1,147 @@
+package headless
+
+/*
+#cgo LDFLAGS: -lEGL
+#include <stdlib.h>
+#include <EGL/egl.h>
+#include <EGL/eglext.h>
+
+// Prefer Mesa's surfaceless platform, which needs neither a display server nor a GPU
+static EGLDisplay openDisplay() {
+	PFNEGLGETPLATFORMDISPLAYEXTPROC getPlatformDisplay =
+		(PFNEGLGETPLATFORMDISPLAYEXTPROC)eglGetProcAddress("eglGetPlatformDisplayEXT");
+	if (getPlatformDisplay != NULL) {
+		EGLDisplay display = getPlatformDisplay(EGL_PLATFORM_SURFACELESS_MESA, EGL_DEFAULT_DISPLAY, NULL);
+		if (display != EGL_NO_DISPLAY) {
+			return display;
+		}
+	}
+	return eglGetDisplay(EGL_DEFAULT_DISPLAY);
+}
+
+static EGLContext createContext(EGLDisplay display, EGLConfig config, int major, int minor, int core) {
+	EGLint attribs[] = {
+		EGL_CONTEXT_MAJOR_VERSION, major,
+		EGL_CONTEXT_MINOR_VERSION, minor,
+		EGL_CONTEXT_OPENGL_PROFILE_MASK,
+		core ? EGL_CONTEXT_OPENGL_CORE_PROFILE_BIT : EGL_CONTEXT_OPENGL_COMPATIBILITY_PROFILE_BIT,
+		EGL_NONE,
+	};
+	return eglCreateContext(display, config, EGL_NO_CONTEXT, attribs);
+}
+
+static EGLBoolean chooseConfig(EGLDisplay display, EGLConfig *config) {
+	EGLint attribs[] = {
+		EGL_SURFACE_TYPE, EGL_PBUFFER_BIT,
+		EGL_RENDERABLE_TYPE, EGL_OPENGL_BIT,
+		EGL_RED_SIZE, 8,
+		EGL_GREEN_SIZE, 8,
+		EGL_BLUE_SIZE, 8,
+		EGL_DEPTH_SIZE, 24,
+		EGL_NONE,
+	};
+	EGLint count = 0;
+	if (!eglChooseConfig(display, attribs, config, 1, &count)) {
+		return EGL_FALSE;
+	}
+	return count > 0;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"unsafe"
+
+	"github.com/thegrandpackard/gogl/gl"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// Context - A surfaceless EGL context. All rendering goes to framebuffer objects.
+// The context is current on the OS thread that created it, so callers must lock that thread.
+type Context struct {
+	display C.EGLDisplay
+	context C.EGLContext
+}
+
+// NewContext - Creates a context with the first profile the driver accepts, makes it current and
+// initializes the GL binding. With Mesa, LIBGL_ALWAYS_SOFTWARE=1 forces the llvmpipe rasterizer.
+func NewContext(profiles []helpers.ContextProfile) (*Context, error) {
+	display := C.openDisplay()
+	if display == C.EGLDisplay(C.EGL_NO_DISPLAY) {
+		return nil, fmt.Errorf("no EGL display available")
+	}
+
+	var major, minor C.EGLint
+	if C.eglInitialize(display, &major, &minor) == C.EGL_FALSE {
+		return nil, fmt.Errorf("eglInitialize failed: 0x%x", int(C.eglGetError()))
+	}
+
+	extensions := C.GoString(C.eglQueryString(display, C.EGL_EXTENSIONS))
+	if !strings.Contains(extensions, "EGL_KHR_surfaceless_context") {
+		C.eglTerminate(display)
+		return nil, fmt.Errorf("EGL %d.%d display does not support EGL_KHR_surfaceless_context", major, minor)
+	}
+
+	if C.eglBindAPI(C.EGL_OPENGL_API) == C.EGL_FALSE {
+		C.eglTerminate(display)
+		return nil, fmt.Errorf("eglBindAPI(EGL_OPENGL_API) failed: 0x%x", int(C.eglGetError()))
+	}
+
+	var config C.EGLConfig
+	if C.chooseConfig(display, &config) == C.EGL_FALSE {
+		C.eglTerminate(display)
+		return nil, fmt.Errorf("no EGL config supports desktop OpenGL")
+	}
+
+	var failures []string
+	for _, profile := range profiles {
+		core := C.int(0)
+		if profile.Core {
+			core = 1
+		}
+		context := C.createContext(display, config, C.int(profile.Major), C.int(profile.Minor), core)
+		if context == C.EGLContext(C.EGL_NO_CONTEXT) {
+			failures = append(failures, fmt.Sprintf("%v: eglCreateContext failed: 0x%x", profile, int(C.eglGetError())))
+			continue
+		}
+
+		noSurface := C.EGLSurface(C.EGL_NO_SURFACE)
+		if C.eglMakeCurrent(display, noSurface, noSurface, context) == C.EGL_FALSE {
+			failures = append(failures, fmt.Sprintf("%v: eglMakeCurrent failed: 0x%x", profile, int(C.eglGetError())))
+			C.eglDestroyContext(display, context)
+			continue
+		}
+
+		if err := gl.InitWithProcAddrFunc(getProcAddress); err != nil {
+			failures = append(failures, fmt.Sprintf("%v: %v", profile, err))
+			C.eglMakeCurrent(display, noSurface, noSurface, C.EGLContext(C.EGL_NO_CONTEXT))
+			C.eglDestroyContext(display, context)
+			continue
+		}
+
+		helpers.Caps = helpers.DetectCapabilities(profile)
+		log.Printf("created headless OpenGL %d.%d context (requested %v) on %s",
+			helpers.Caps.Major, helpers.Caps.Minor, profile, helpers.Caps.Renderer)
+		return &Context{display: display, context: context}, nil
+	}
+
+	C.eglTerminate(display)
+	return nil, fmt.Errorf("failed to create a headless OpenGL context: %s; %s", strings.Join(failures, "; "), helpers.RebuildHint())
+}
+
+// Destroy - Releases the context and the display connection
+func (c *Context) Destroy() {
+	noSurface := C.EGLSurface(C.EGL_NO_SURFACE)
+	C.eglMakeCurrent(c.display, noSurface, noSurface, C.EGLContext(C.EGL_NO_CONTEXT))
+	C.eglDestroyContext(c.display, c.context)
+	C.eglTerminate(c.display)
+}
+
+func getProcAddress(name string) unsafe.Pointer {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	return unsafe.Pointer(C.eglGetProcAddress(cname))
+}
@@ -0,0 +1,20 @@
+//go:build !linux
+
+package headless
+
+import (
+	"fmt"
+
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// Context - Headless rendering is only implemented through EGL on Linux
+type Context struct{}
+
+// NewContext - Always fails outside Linux
+func NewContext(profiles []helpers.ContextProfile) (*Context, error) {
+	return nil, fmt.Errorf("headless rendering requires EGL, which is only supported on linux")
+}
+
+// Destroy - Does nothing
+func (c *Context) Destroy() {}
@@ -0,0 +1,194 @@
+package headless
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/thegrandpackard/gogl/controls"
+	"github.com/thegrandpackard/gogl/gl"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// Scene - Anything the runner can drive frame by frame, the same shape as app.App. Every frame
+// runs exactly one Update, so Render is always called with an alpha of 1.
+type Scene interface {
+	Init() error
+	Update(dt float64)
+	Render(alpha float64)
+	Shutdown()
+}
+
+// Runner - Renders a fixed number of frames of a scene into an offscreen framebuffer
+type Runner struct {
+	Width, Height int
+	Samples       int
+	Frames        int
+
+	// TimeStep - Simulated seconds between two frames
+	TimeStep float64
+
+	// OutputDir - When set every frame is written there as frame_0000.png, frame_0001.png, ...
+	OutputDir string
+
+	// Capture - When set it is called with every frame after it has been read back
+	Capture func(frame int, img *image.RGBA) error
+
+	// Input - When set it is handed to scenes with a SetInput method and polled before every
+	// Update, so a replay can drive an interactive scene
+	Input *controls.Device
+
+	// TileSize - Frames wider or higher than this many pixels are rendered tile by tile and
+	// stitched together, so Width and Height can go past the largest viewport and renderbuffer
+	// of the context. Zero tiles only what the context cannot render at once. Scenes rendered in
+	// more than one tile need a SetTile method that narrows their projection to the tile.
+	TileSize int
+
+	Profiles []helpers.ContextProfile
+}
+
+// NewRunner - Returns a runner rendering one 1024x768 frame at 60 simulated frames per second
+func NewRunner() *Runner {
+	return &Runner{
+		Width:    1024,
+		Height:   768,
+		Frames:   1,
+		TimeStep: 1.0 / 60,
+		Profiles: helpers.DefaultProfiles,
+	}
+}
+
+// Run - Creates a headless context, renders every frame of scene and returns the last one
+func (r *Runner) Run(scene Scene) (*image.RGBA, error) {
+	// The EGL context is current on this thread only
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	context, err := NewContext(r.Profiles)
+	if err != nil {
+		return nil, err
+	}
+	defer context.Destroy()
+
+	return r.RunCurrent(scene)
+}
+
+// RunCurrent - Like Run, but renders with the context that is already current on this thread
+func (r *Runner) RunCurrent(scene Scene) (*image.RGBA, error) {
+	if r.Frames < 1 {
+		return nil, fmt.Errorf("runner needs at least one frame, got %d", r.Frames)
+	}
+
+	if r.OutputDir != "" {
+		if err := os.MkdirAll(r.OutputDir, 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	tileSize := helpers.MaxTileSize()
+	if r.TileSize > 0 && r.TileSize < tileSize {
+		tileSize = r.TileSize
+	}
+	tiles, err := helpers.Tiles(r.Width, r.Height, tileSize)
+	if err != nil {
+		return nil, err
+	}
+	tiler, ok := scene.(tiledScene)
+	if len(tiles) > 1 && !ok {
+		return nil, fmt.Errorf("%dx%d frames need %d tiles of %d pixels but the scene cannot render tiles", r.Width, r.Height, len(tiles), tileSize)
+	}
+
+	framebuffer, err := helpers.NewFramebuffer(tiles[0].Width, tiles[0].Height, r.Samples)
+	if err != nil {
+		return nil, err
+	}
+	defer framebuffer.Delete()
+	defer helpers.UnbindFramebuffer()
+
+	// Scenes that size their projection get the size of the whole frame, not of a tile
+	if resizer, ok := scene.(interface {
+		Resize(width, height int)
+	}); ok {
+		resizer.Resize(r.Width, r.Height)
+	}
+
+	if r.Input != nil {
+		if inputScene, ok := scene.(interface {
+			SetInput(device *controls.Device)
+		}); ok {
+			inputScene.SetInput(r.Input)
+		}
+	}
+
+	// Shutdown also releases whatever a failed Init managed to create
+	framebuffer.Bind()
+	defer scene.Shutdown()
+	if err := scene.Init(); err != nil {
+		return nil, err
+	}
+
+	var last *image.RGBA
+	for frame := 0; frame < r.Frames; frame++ {
+		// Scenes may have bound other targets while initializing or rendering
+		framebuffer.Bind()
+
+		if r.Input != nil {
+			r.Input.Poll()
+		}
+		scene.Update(r.TimeStep)
+		if len(tiles) == 1 {
+			scene.Render(1)
+		}
+
+		if frame < r.Frames-1 && r.OutputDir == "" && r.Capture == nil {
+			continue
+		}
+
+		if len(tiles) == 1 {
+			last = framebuffer.ReadPixels()
+		} else {
+			last = renderTiles(tiler, framebuffer, tiles)
+		}
+		if r.OutputDir != "" {
+			if err := helpers.SavePNG(filepath.Join(r.OutputDir, fmt.Sprintf("frame_%04d.png", frame)), last); err != nil {
+				return nil, err
+			}
+		}
+		if r.Capture != nil {
+			if err := r.Capture(frame, last); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return last, nil
+}
+
+// tiledScene - A scene that can render one tile of its image at a time
+type tiledScene interface {
+	Scene
+	SetTile(tile *helpers.Tile)
+}
+
+// renderTiles - Renders every tile of a frame into framebuffer and stitches them into one image
+func renderTiles(scene tiledScene, framebuffer *helpers.Framebuffer, tiles []helpers.Tile) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, tiles[0].ImageWidth, tiles[0].ImageHeight))
+	for i := range tiles {
+		tile := tiles[i]
+		framebuffer.Bind()
+		// Tiles on the right and bottom edges may be smaller. They render into the bottom left
+		// corner, which ends up at the bottom of the readback.
+		gl.Viewport(0, 0, int32(tile.Width), int32(tile.Height))
+		scene.SetTile(&tile)
+		scene.Render(1)
+
+		pixels := framebuffer.ReadPixels()
+		bounds := image.Rect(tile.X, tile.Y, tile.X+tile.Width, tile.Y+tile.Height)
+		draw.Draw(img, bounds, pixels, image.Pt(0, framebuffer.Height-tile.Height), draw.Src)
+	}
+	scene.SetTile(nil)
+	return img
+}
@@ -0,0 +1,63 @@
+package headless
+
+import (
+	"image"
+	"runtime"
+	"testing"
+
+	"github.com/thegrandpackard/gogl/helpers"
+	"github.com/thegrandpackard/gogl/scenes"
+)
+
+func TestTiledMatchesWhole(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	context, err := NewContext(helpers.DefaultProfiles)
+	if err != nil {
+		t.Skipf("no headless context: %v", err)
+	}
+	defer context.Destroy()
+
+	// Not a multiple of the tile size, so the last row and column of tiles are partial
+	const width, height = 200, 150
+	tests := []struct {
+		name  string
+		scene func() Scene
+	}{
+		{"cube_color", func() Scene { return scenes.NewCubeColor(width, height) }},
+		{"cube", func() Scene { return scenes.NewCube(width, height, "../cube") }},
+	}
+	// Subtests would run on other threads than the one the context is current on
+	for _, test := range tests {
+		render := func(tileSize int) *image.RGBA {
+			runner := NewRunner()
+			runner.Width, runner.Height = width, height
+			runner.TileSize = tileSize
+			img, err := runner.RunCurrent(test.scene())
+			if err != nil {
+				t.Fatalf("%s: %v", test.name, err)
+			}
+			if err := helpers.CheckLeaks(); err != nil {
+				t.Fatalf("%s: %v", test.name, err)
+			}
+			return img
+		}
+		whole, tiled := render(0), render(64)
+
+		// Edges crossing a pixel center exactly may be rasterized by either triangle, so a
+		// handful of pixels can differ without a seam
+		differing := 0
+		for i := 0; i < len(whole.Pix); i += 4 {
+			for c := 0; c < 4; c++ {
+				if d := int(whole.Pix[i+c]) - int(tiled.Pix[i+c]); d > 8 || d < -8 {
+					differing++
+					break
+				}
+			}
+		}
+		if allowed := width * height / 1000; differing > allowed {
+			t.Errorf("%s: %d pixels of the tiled frame differ from the whole one, at most %d may", test.name, differing, allowed)
+		}
+	}
+}
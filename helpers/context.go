@@ -0,0 +1,118 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thegrandpackard/gogl/gl"
+)
+
+// ContextProfile - An OpenGL context version to request from GLFW or EGL
+type ContextProfile struct {
+	Major, Minor int
+	Core         bool
+}
+
+func (p ContextProfile) String() string {
+	if p.Core {
+		return fmt.Sprintf("%d.%d core", p.Major, p.Minor)
+	}
+	return fmt.Sprintf("%d.%d", p.Major, p.Minor)
+}
+
+// DefaultProfiles - Profiles the runners try with the binding this package was built against,
+// most capable first
+var DefaultProfiles = bindingProfiles(gl.Profile)
+
+// bindingProfiles - The profiles a binding can load its functions from. gl.Init fails when the
+// context lacks a function of the binding, so a core binding needs a core context of at least
+// its version, and the 2.1 binding needs the legacy functions core contexts leave out.
+func bindingProfiles(binding string) []ContextProfile {
+	var major, minor int
+	fmt.Sscanf(binding, "%d.%d", &major, &minor)
+	if !strings.HasSuffix(binding, "-core") {
+		return []ContextProfile{{major, minor, false}}
+	}
+
+	var profiles []ContextProfile
+	for _, profile := range []ContextProfile{{4, 1, true}, {3, 3, true}} {
+		if profile.Major > major || profile.Major == major && profile.Minor >= minor {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
+// RebuildHint - Which bindings to build with for drivers older than the one this package was
+// built against, for the errors of runners that found no context it can load
+func RebuildHint() string {
+	switch gl.Profile {
+	case "4.1-core":
+		return "this build needs OpenGL 4.1, build with -tags gl33 for OpenGL 3.3 or -tags gl21 for OpenGL 2.1"
+	case "3.3-core":
+		return "this build needs OpenGL 3.3, build with -tags gl21 for OpenGL 2.1"
+	}
+	return "this build needs OpenGL 2.1"
+}
+
+// Capabilities - Describes the context that was actually created
+type Capabilities struct {
+	Profile      ContextProfile
+	Major, Minor int
+	GLSLVersion  int
+	Renderer     string
+	Extensions   map[string]bool
+
+	VertexArrayObjects bool
+	Instancing         bool
+	UniformBuffers     bool
+	DebugOutput        bool
+	ProgramBinaries    bool
+}
+
+// Caps - Capabilities of the context the window or headless runner created
+var Caps Capabilities
+
+// DetectCapabilities - Queries the current context, whether created by GLFW or not, for its version and optional features
+func DetectCapabilities(profile ContextProfile) Capabilities {
+	caps := Capabilities{
+		Profile:  profile,
+		Renderer: gl.GoStr(gl.GetString(gl.RENDERER)),
+	}
+	caps.Major, caps.Minor = parseVersion(gl.GoStr(gl.GetString(gl.VERSION)))
+
+	// GL_MAJOR_VERSION does not exist before 3.0, so parse the strings instead
+	glslMajor, glslMinor := parseVersion(gl.GoStr(gl.GetString(gl.SHADING_LANGUAGE_VERSION)))
+	caps.GLSLVersion = glslMajor*100 + glslMinor
+
+	atLeast := func(major, minor int) bool {
+		return caps.Major > major || (caps.Major == major && caps.Minor >= minor)
+	}
+
+	// Query extensions through GL rather than GLFW so contexts created elsewhere work too
+	caps.Extensions = make(map[string]bool)
+	for _, name := range queryExtensions(caps.Major) {
+		caps.Extensions[name] = true
+	}
+	extension := func(name string) bool { return caps.Extensions[name] }
+
+	caps.VertexArrayObjects = atLeast(3, 0) || extension("GL_ARB_vertex_array_object")
+	caps.Instancing = atLeast(3, 3) || (extension("GL_ARB_instanced_arrays") && extension("GL_ARB_draw_instanced"))
+	caps.UniformBuffers = atLeast(3, 1) || extension("GL_ARB_uniform_buffer_object")
+	caps.DebugOutput = atLeast(4, 3) || extension("GL_KHR_debug") || extension("GL_ARB_debug_output")
+	caps.ProgramBinaries = atLeast(4, 1) || extension("GL_ARB_get_program_binary")
+
+	return caps
+}
+
+// parseVersion - Returns the leading "major.minor" of a GL version string such as "3.3.0 NVIDIA 390.48"
+// or "OpenGL ES 3.2 Mesa 18.0.5"
+func parseVersion(version string) (int, int) {
+	for _, field := range strings.Fields(version) {
+		var major, minor int
+		if n, _ := fmt.Sscanf(field, "%d.%d", &major, &minor); n == 2 {
+			return major, minor
+		}
+	}
+	return 0, 0
+}
@@ -0,0 +1,36 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thegrandpackard/gogl/gl"
+)
+
+func TestBindingProfiles(t *testing.T) {
+	tests := []struct {
+		binding string
+		want    []ContextProfile
+	}{
+		// Older contexts lack functions the 4.1 binding loads
+		{"4.1-core", []ContextProfile{{4, 1, true}}},
+		{"3.3-core", []ContextProfile{{4, 1, true}, {3, 3, true}}},
+		// Core contexts lack the legacy functions the 2.1 binding loads
+		{"2.1", []ContextProfile{{2, 1, false}}},
+	}
+	for _, test := range tests {
+		if got := bindingProfiles(test.binding); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: profiles are %v, want %v", test.binding, got, test.want)
+		}
+	}
+
+	// The binding picked by the build tags, run the tests with -tags gl33 and -tags gl21 too
+	for _, profile := range DefaultProfiles {
+		if profile.Core != (gl.Profile != "2.1") {
+			t.Errorf("the %s binding tries the %v profile", gl.Profile, profile)
+		}
+	}
+	if len(DefaultProfiles) == 0 {
+		t.Errorf("the %s binding tries no profile", gl.Profile)
+	}
+}
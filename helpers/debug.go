@@ -0,0 +1,185 @@
+package helpers
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+	"unsafe"
+
+	"github.com/thegrandpackard/gogl/gl"
+)
+
+// DebugMessage - A message the driver reported through the debug output
+type DebugMessage struct {
+	Source, Type, ID, Severity uint32
+	Text                       string
+
+	// Stack - The Go stack of the GL call that caused the message, when the filter asked for stacks
+	Stack []byte
+}
+
+func (m DebugMessage) String() string {
+	return fmt.Sprintf("%s %s from %s (%d): %s", debugSeverityName(m.Severity), debugTypeName(m.Type), debugSourceName(m.Source), m.ID, m.Text)
+}
+
+// DebugFilter - Selects which debug messages are reported. Empty lists accept everything.
+type DebugFilter struct {
+	Sources []uint32
+	Types   []uint32
+
+	// MinSeverity - The least severe gl.DEBUG_SEVERITY_* reported
+	MinSeverity uint32
+
+	// Stacks - Attach Go stack traces to messages. This makes the output synchronous, which is slower.
+	Stacks bool
+
+	// Handler - Receives the messages. They are logged when it is nil.
+	Handler func(DebugMessage)
+}
+
+// DefaultDebugFilter - Everything but notifications, with stack traces
+var DefaultDebugFilter = DebugFilter{
+	MinSeverity: gl.DEBUG_SEVERITY_LOW,
+	Stacks:      true,
+}
+
+// Least severe first
+var debugSeverities = []uint32{
+	gl.DEBUG_SEVERITY_NOTIFICATION,
+	gl.DEBUG_SEVERITY_LOW,
+	gl.DEBUG_SEVERITY_MEDIUM,
+	gl.DEBUG_SEVERITY_HIGH,
+}
+
+// EnableDebugOutput - Installs a debug message callback when the current context supports GL 4.3,
+// KHR_debug or ARB_debug_output, and reports whether it did. Build with -tags gldebug to have
+// glGetError checked after every call on contexts without any of them.
+func EnableDebugOutput(filter DebugFilter) bool {
+	khr := Caps.Major > 4 || (Caps.Major == 4 && Caps.Minor >= 3) || Caps.Extensions["GL_KHR_debug"]
+	arb := Caps.Extensions["GL_ARB_debug_output"]
+	if !khr && !arb {
+		return false
+	}
+
+	callback := func(source, gltype, id, severity uint32, length int32, message string, userParam unsafe.Pointer) {
+		m := DebugMessage{Source: source, Type: gltype, ID: id, Severity: severity, Text: message}
+		if filter.Stacks {
+			m.Stack = debug.Stack()
+		}
+		if filter.Handler != nil {
+			filter.Handler(m)
+		} else if m.Stack != nil {
+			log.Printf("GL %v\n%s", m, m.Stack)
+		} else {
+			log.Printf("GL %v", m)
+		}
+	}
+
+	control := gl.DebugMessageControl
+	if khr {
+		gl.Enable(gl.DEBUG_OUTPUT)
+		gl.DebugMessageCallback(callback, nil)
+	} else {
+		// ARB_debug_output is always enabled and shares the enums, but has no notifications
+		control = gl.DebugMessageControlARB
+		gl.DebugMessageCallbackARB(callback, nil)
+	}
+
+	// Synchronous output runs the callback inside the failing call, so the stack points at it
+	if filter.Stacks {
+		gl.Enable(gl.DEBUG_OUTPUT_SYNCHRONOUS)
+	} else {
+		gl.Disable(gl.DEBUG_OUTPUT_SYNCHRONOUS)
+	}
+
+	sources := filter.Sources
+	if len(sources) == 0 {
+		sources = []uint32{gl.DONT_CARE}
+	}
+	types := filter.Types
+	if len(types) == 0 {
+		types = []uint32{gl.DONT_CARE}
+	}
+
+	control(gl.DONT_CARE, gl.DONT_CARE, gl.DONT_CARE, 0, nil, false)
+	for _, severity := range debugSeverities {
+		if debugSeverityRank(severity) < debugSeverityRank(filter.MinSeverity) {
+			continue
+		}
+		if !khr && severity == gl.DEBUG_SEVERITY_NOTIFICATION {
+			continue
+		}
+		for _, source := range sources {
+			for _, gltype := range types {
+				control(source, gltype, severity, 0, nil, true)
+			}
+		}
+	}
+
+	return true
+}
+
+func debugSeverityRank(severity uint32) int {
+	for i, s := range debugSeverities {
+		if s == severity {
+			return i
+		}
+	}
+	return 0
+}
+
+func debugSeverityName(severity uint32) string {
+	switch severity {
+	case gl.DEBUG_SEVERITY_HIGH:
+		return "high"
+	case gl.DEBUG_SEVERITY_MEDIUM:
+		return "medium"
+	case gl.DEBUG_SEVERITY_LOW:
+		return "low"
+	case gl.DEBUG_SEVERITY_NOTIFICATION:
+		return "notification"
+	}
+	return fmt.Sprintf("severity 0x%x", severity)
+}
+
+func debugTypeName(gltype uint32) string {
+	switch gltype {
+	case gl.DEBUG_TYPE_ERROR:
+		return "error"
+	case gl.DEBUG_TYPE_DEPRECATED_BEHAVIOR:
+		return "deprecated behavior"
+	case gl.DEBUG_TYPE_UNDEFINED_BEHAVIOR:
+		return "undefined behavior"
+	case gl.DEBUG_TYPE_PORTABILITY:
+		return "portability"
+	case gl.DEBUG_TYPE_PERFORMANCE:
+		return "performance"
+	case gl.DEBUG_TYPE_MARKER:
+		return "marker"
+	case gl.DEBUG_TYPE_PUSH_GROUP:
+		return "push group"
+	case gl.DEBUG_TYPE_POP_GROUP:
+		return "pop group"
+	case gl.DEBUG_TYPE_OTHER:
+		return "other"
+	}
+	return fmt.Sprintf("type 0x%x", gltype)
+}
+
+func debugSourceName(source uint32) string {
+	switch source {
+	case gl.DEBUG_SOURCE_API:
+		return "API"
+	case gl.DEBUG_SOURCE_WINDOW_SYSTEM:
+		return "window system"
+	case gl.DEBUG_SOURCE_SHADER_COMPILER:
+		return "shader compiler"
+	case gl.DEBUG_SOURCE_THIRD_PARTY:
+		return "third party"
+	case gl.DEBUG_SOURCE_APPLICATION:
+		return "application"
+	case gl.DEBUG_SOURCE_OTHER:
+		return "other"
+	}
+	return fmt.Sprintf("source 0x%x", source)
+}
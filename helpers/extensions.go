@@ -0,0 +1,25 @@
+//go:build !gl21
+
+package helpers
+
+import (
+	"strings"
+
+	"github.com/thegrandpackard/gogl/gl"
+)
+
+// queryExtensions - Lists the extensions of the current context. Core profiles only support the
+// indexed query, which does not exist before 3.0.
+func queryExtensions(major int) []string {
+	if major < 3 {
+		return strings.Fields(gl.GoStr(gl.GetString(gl.EXTENSIONS)))
+	}
+
+	var count int32
+	gl.GetIntegerv(gl.NUM_EXTENSIONS, &count)
+	extensions := make([]string, 0, count)
+	for i := int32(0); i < count; i++ {
+		extensions = append(extensions, gl.GoStr(gl.GetStringi(gl.EXTENSIONS, uint32(i))))
+	}
+	return extensions
+}
@@ -0,0 +1,15 @@
+//go:build gl21
+
+package helpers
+
+import (
+	"strings"
+
+	"github.com/thegrandpackard/gogl/gl"
+)
+
+// queryExtensions - Lists the extensions of the current context. The 2.1 binding only loads
+// compatibility contexts, which always support the single string query.
+func queryExtensions(major int) []string {
+	return strings.Fields(gl.GoStr(gl.GetString(gl.EXTENSIONS)))
+}
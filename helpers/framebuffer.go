@@ -0,0 +1,170 @@
+package helpers
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+
+	"github.com/thegrandpackard/gogl/gl"
+)
+
+// Framebuffer - An offscreen render target with a color and a depth/stencil attachment
+type Framebuffer struct {
+	ID            uint32
+	Width, Height int
+	Samples       int
+
+	color, depth uint32
+
+	// resolve is a single sampled copy that multisampled targets are blitted into for readback
+	resolve *Framebuffer
+}
+
+// NewFramebuffer - Returns a complete framebuffer, multisampled when samples is above zero
+func NewFramebuffer(width, height, samples int) (*Framebuffer, error) {
+	f := &Framebuffer{Width: width, Height: height, Samples: samples}
+
+	f.ID = GenFramebuffer()
+	gl.BindFramebuffer(gl.FRAMEBUFFER, f.ID)
+
+	f.color = GenRenderbuffer()
+	gl.BindRenderbuffer(gl.RENDERBUFFER, f.color)
+	f.storage(gl.RGBA8)
+	gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.RENDERBUFFER, f.color)
+
+	f.depth = GenRenderbuffer()
+	gl.BindRenderbuffer(gl.RENDERBUFFER, f.depth)
+	f.storage(gl.DEPTH24_STENCIL8)
+	gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.DEPTH_STENCIL_ATTACHMENT, gl.RENDERBUFFER, f.depth)
+
+	gl.BindRenderbuffer(gl.RENDERBUFFER, 0)
+
+	if status := gl.CheckFramebufferStatus(gl.FRAMEBUFFER); status != gl.FRAMEBUFFER_COMPLETE {
+		gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+		f.Delete()
+		return nil, fmt.Errorf("framebuffer %dx%d with %d samples is incomplete: 0x%x", width, height, samples, status)
+	}
+
+	if samples > 0 {
+		resolve, err := NewFramebuffer(width, height, 0)
+		if err != nil {
+			f.Delete()
+			return nil, err
+		}
+		f.resolve = resolve
+		gl.BindFramebuffer(gl.FRAMEBUFFER, f.ID)
+	}
+
+	return f, nil
+}
+
+func (f *Framebuffer) storage(format uint32) {
+	if f.Samples > 0 {
+		gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, int32(f.Samples), format, int32(f.Width), int32(f.Height))
+	} else {
+		gl.RenderbufferStorage(gl.RENDERBUFFER, format, int32(f.Width), int32(f.Height))
+	}
+}
+
+// Resize - Reallocates the attachments at a new size, for targets that follow the window. The
+// previous contents are lost.
+func (f *Framebuffer) Resize(width, height int) error {
+	if width == f.Width && height == f.Height {
+		return nil
+	}
+	f.Width, f.Height = width, height
+
+	gl.BindRenderbuffer(gl.RENDERBUFFER, f.color)
+	f.storage(gl.RGBA8)
+	gl.BindRenderbuffer(gl.RENDERBUFFER, f.depth)
+	f.storage(gl.DEPTH24_STENCIL8)
+	gl.BindRenderbuffer(gl.RENDERBUFFER, 0)
+
+	if f.resolve != nil {
+		if err := f.resolve.Resize(width, height); err != nil {
+			return err
+		}
+	}
+
+	gl.BindFramebuffer(gl.FRAMEBUFFER, f.ID)
+	if status := gl.CheckFramebufferStatus(gl.FRAMEBUFFER); status != gl.FRAMEBUFFER_COMPLETE {
+		return fmt.Errorf("framebuffer resized to %dx%d with %d samples is incomplete: 0x%x", width, height, f.Samples, status)
+	}
+	return nil
+}
+
+// Bind - Directs rendering into the framebuffer and sets the viewport to cover it
+func (f *Framebuffer) Bind() {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, f.ID)
+	gl.Viewport(0, 0, int32(f.Width), int32(f.Height))
+}
+
+// UnbindFramebuffer - Directs rendering back to the default framebuffer
+func UnbindFramebuffer() {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+}
+
+// ReadPixels - Returns the color attachment as an image with the origin at the top left.
+// Alpha is forced to opaque so captures look like what a window would show.
+func (f *Framebuffer) ReadPixels() *image.RGBA {
+	source := f
+	if f.resolve != nil {
+		gl.BindFramebuffer(gl.READ_FRAMEBUFFER, f.ID)
+		gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, f.resolve.ID)
+		gl.BlitFramebuffer(0, 0, int32(f.Width), int32(f.Height), 0, 0, int32(f.Width), int32(f.Height), gl.COLOR_BUFFER_BIT, gl.NEAREST)
+		source = f.resolve
+	}
+
+	gl.BindFramebuffer(gl.FRAMEBUFFER, source.ID)
+	img := ReadPixels(0, 0, f.Width, f.Height)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, f.ID)
+
+	return img
+}
+
+// ReadPixels - Reads a rectangle of the bound read framebuffer, flipped so the origin is at the top left
+func ReadPixels(x, y, width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	gl.PixelStorei(gl.PACK_ALIGNMENT, 1)
+	gl.ReadPixels(int32(x), int32(y), int32(width), int32(height), gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(img.Pix))
+
+	// GL rows start at the bottom
+	row := make([]byte, img.Stride)
+	for top, bottom := 0, height-1; top < bottom; top, bottom = top+1, bottom-1 {
+		a := img.Pix[top*img.Stride : (top+1)*img.Stride]
+		b := img.Pix[bottom*img.Stride : (bottom+1)*img.Stride]
+		copy(row, a)
+		copy(a, b)
+		copy(b, row)
+	}
+	for i := 3; i < len(img.Pix); i += 4 {
+		img.Pix[i] = 0xff
+	}
+
+	return img
+}
+
+// Delete - Releases the framebuffer and its attachments
+func (f *Framebuffer) Delete() {
+	if f.resolve != nil {
+		f.resolve.Delete()
+		f.resolve = nil
+	}
+	DeleteRenderbuffer(f.color)
+	DeleteRenderbuffer(f.depth)
+	DeleteFramebuffer(f.ID)
+}
+
+// SavePNG - Writes img to file as a PNG
+func SavePNG(file string, img image.Image) error {
+	out, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("failed to create %q: %v", file, err)
+	}
+	if err := png.Encode(out, img); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to encode %q: %v", file, err)
+	}
+	return out.Close()
+}
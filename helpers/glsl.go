@@ -0,0 +1,95 @@
+package helpers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/thegrandpackard/gogl/gl"
+)
+
+var versionDirective = regexp.MustCompile(`(?m)^[ \t]*#version[ \t]+(\d+)[^\n]*`)
+var attribLocation = regexp.MustCompile(`layout\s*\(\s*location\s*=\s*(\d+)\s*\)\s*in\s+\w+\s+(\w+)`)
+var fragmentOutput = regexp.MustCompile(`(?m)^[ \t]*out[ \t]+(float|vec2|vec3|vec4)[ \t]+(\w+)[ \t]*;`)
+
+// PrepareShader - Adapts the #version directive of source to the current context. Sources written
+// for a newer GLSL than the context supports are lowered to the newest supported version, or
+// translated to GLSL 1.20 when the context predates GLSL 3.30. Sources are left alone when no
+// runner has detected the capabilities of the context.
+func PrepareShader(source string, shaderType uint32) string {
+	if Caps.GLSLVersion == 0 {
+		return source
+	}
+
+	match := versionDirective.FindStringSubmatchIndex(source)
+	if match == nil {
+		return source
+	}
+	version, err := strconv.Atoi(source[match[2]:match[3]])
+	if err != nil || version <= Caps.GLSLVersion {
+		return source
+	}
+
+	if Caps.GLSLVersion >= 330 {
+		return source[:match[0]] + fmt.Sprintf("#version %d core", Caps.GLSLVersion) + source[match[1]:]
+	}
+	return translateLegacy(source[:match[0]]+"#version 120"+source[match[1]:], shaderType)
+}
+
+// translateLegacy - Rewrites a GLSL 3.30 shader whose #version has already been replaced so it
+// compiles as GLSL 1.20. Attribute locations are restored by linkProgram through BindAttribLocation.
+func translateLegacy(source string, shaderType uint32) string {
+	preamble := "#define layout(qualifier)\n#define texture texture2D\n"
+	if shaderType == gl.VERTEX_SHADER {
+		preamble += "#define in attribute\n#define out varying\n"
+	} else {
+		preamble += "#define in varying\n"
+	}
+
+	epilogue := ""
+	if shaderType == gl.FRAGMENT_SHADER {
+		// GLSL 1.20 has no user defined outputs, so keep the output as a global and copy it
+		// to gl_FragColor after the original main has run
+		if match := fragmentOutput.FindStringSubmatch(source); match != nil {
+			outputType, name := match[1], match[2]
+			source = strings.Replace(source, match[0], outputType+" "+name+";", 1)
+
+			color := name
+			switch outputType {
+			case "float":
+				color = "vec4(" + name + ", 0.0, 0.0, 1.0)"
+			case "vec2":
+				color = "vec4(" + name + ", 0.0, 1.0)"
+			case "vec3":
+				color = "vec4(" + name + ", 1.0)"
+			}
+			preamble += "#define main shaderMain\n"
+			epilogue = "\n#undef main\nvoid main() {\n    shaderMain();\n    gl_FragColor = " + color + ";\n}\n"
+		}
+	}
+
+	terminator := ""
+	if strings.HasSuffix(source, "\x00") {
+		source, terminator = strings.TrimSuffix(source, "\x00"), "\x00"
+	}
+
+	end := strings.Index(source, "#version 120") + len("#version 120\n")
+	if end > len(source) {
+		source += "\n"
+	}
+	return source[:end] + preamble + source[end:] + epilogue + terminator
+}
+
+// attribLocations - Returns the explicit attribute locations declared in a vertex shader
+func attribLocations(vertexShaderSource string) map[string]uint32 {
+	locations := make(map[string]uint32)
+	for _, match := range attribLocation.FindAllStringSubmatch(vertexShaderSource, -1) {
+		location, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		locations[match[2]] = uint32(location)
+	}
+	return locations
+}
@@ -0,0 +1,225 @@
+package helpers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/thegrandpackard/gogl/gl"
+)
+
+// programBinaryMagic - Marks the start of every cache file, followed by a format version
+const programBinaryMagic = "GLPB"
+const programBinaryVersion uint32 = 1
+
+// programBinaryHeader - Fixed size header written in front of the driver's program binary
+type programBinaryHeader struct {
+	Magic    [4]byte
+	Version  uint32
+	Format   uint32
+	Length   uint32
+	Checksum [sha256.Size]byte
+}
+
+// ProgramCache - Stores linked program binaries on disk so later runs can skip compiling and linking
+type ProgramCache struct {
+	Dir string
+}
+
+// NewProgramCache - Returns a program cache that keeps its binaries in dir
+func NewProgramCache(dir string) *ProgramCache {
+	return &ProgramCache{Dir: dir}
+}
+
+// DefaultProgramCacheDir - Returns the per-user directory used for cached program binaries
+func DefaultProgramCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "gogl", "programs")
+}
+
+// NewProgram - Returns the program for the given sources and defines, loading it from the cache when the
+// stored binary is still valid for the current driver and compiling, linking and storing it otherwise
+func (c *ProgramCache) NewProgram(vertexShaderSource, fragmentShaderSource string, defines ...string) (uint32, error) {
+	vertexShaderSource = InjectDefines(vertexShaderSource, defines)
+	fragmentShaderSource = InjectDefines(fragmentShaderSource, defines)
+
+	if !programBinarySupported() {
+		return NewProgram(vertexShaderSource, fragmentShaderSource)
+	}
+
+	path := c.path(programCacheKey(driverIdentity(), vertexShaderSource, fragmentShaderSource))
+
+	format, payload, err := c.load(path)
+	if err == nil {
+		program, err := loadProgramBinary(format, payload)
+		if err == nil {
+			return program, nil
+		}
+		log.Printf("discarding cached program %q: %v", path, err)
+		os.Remove(path)
+	} else if !os.IsNotExist(err) {
+		log.Printf("discarding cached program %q: %v", path, err)
+	}
+
+	program, err := linkProgram(vertexShaderSource, fragmentShaderSource, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// A cache that can't be written only costs the next run a compile
+	format, payload, err = programBinary(program)
+	if err == nil {
+		err = c.store(path, format, payload)
+	}
+	if err != nil {
+		log.Printf("failed to cache program %q: %v", path, err)
+	}
+
+	return program, nil
+}
+
+// Clear - Removes every cached program binary
+func (c *ProgramCache) Clear() error {
+	files, err := filepath.Glob(filepath.Join(c.Dir, "*.bin"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// path - The file a program binary with key is kept in
+func (c *ProgramCache) path(key string) string {
+	return filepath.Join(c.Dir, key+".bin")
+}
+
+// load - Reads the binary kept in path. A file that is not a complete binary is removed so the
+// program gets linked and stored again.
+func (c *ProgramCache) load(path string) (uint32, []byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	format, payload, err := decodeProgramBinary(data)
+	if err != nil {
+		os.Remove(path)
+		return 0, nil, err
+	}
+	return format, payload, nil
+}
+
+// store - Keeps a program binary in path
+func (c *ProgramCache) store(path string, format uint32, payload []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a crash never leaves a half written binary behind
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, encodeProgramBinary(format, payload), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// driverIdentity - The vendor, renderer and version of the current context
+func driverIdentity() string {
+	return gl.GoStr(gl.GetString(gl.VENDOR)) + "\n" + gl.GoStr(gl.GetString(gl.RENDERER)) + "\n" + gl.GoStr(gl.GetString(gl.VERSION))
+}
+
+// programCacheKey - Hashes the final sources together with the driver identification, so a driver
+// update or a different GPU never picks up a binary it did not produce
+func programCacheKey(driver, vertexShaderSource, fragmentShaderSource string) string {
+	hash := sha256.New()
+	for _, part := range []string{vertexShaderSource, fragmentShaderSource, driver} {
+		// Length prefix every part so moving text between sources changes the key
+		binary.Write(hash, binary.LittleEndian, uint64(len(part)))
+		hash.Write([]byte(part))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// encodeProgramBinary - The header followed by the driver's binary
+func encodeProgramBinary(format uint32, payload []byte) []byte {
+	header := programBinaryHeader{
+		Version:  programBinaryVersion,
+		Format:   format,
+		Length:   uint32(len(payload)),
+		Checksum: sha256.Sum256(payload),
+	}
+	copy(header.Magic[:], programBinaryMagic)
+
+	var buffer bytes.Buffer
+	binary.Write(&buffer, binary.LittleEndian, &header)
+	buffer.Write(payload)
+	return buffer.Bytes()
+}
+
+// decodeProgramBinary - Checks the header of a cache file and returns the driver's binary
+func decodeProgramBinary(data []byte) (uint32, []byte, error) {
+	var header programBinaryHeader
+	if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &header); err != nil {
+		return 0, nil, fmt.Errorf("failed to read header: %v", err)
+	}
+	if string(header.Magic[:]) != programBinaryMagic || header.Version != programBinaryVersion {
+		return 0, nil, fmt.Errorf("unknown cache file format")
+	}
+
+	payload := data[binary.Size(header):]
+	if uint32(len(payload)) != header.Length || header.Length == 0 {
+		return 0, nil, fmt.Errorf("truncated program binary")
+	}
+	if sha256.Sum256(payload) != header.Checksum {
+		return 0, nil, fmt.Errorf("program binary checksum mismatch")
+	}
+	return header.Format, payload, nil
+}
+
+func programBinarySupported() bool {
+	var formats int32
+	gl.GetIntegerv(gl.NUM_PROGRAM_BINARY_FORMATS, &formats)
+	return formats > 0
+}
+
+// loadProgramBinary - Creates a program from a binary the driver produced earlier
+func loadProgramBinary(format uint32, payload []byte) (uint32, error) {
+	program := CreateProgram()
+	gl.ProgramBinary(program, format, gl.Ptr(payload), int32(len(payload)))
+
+	// Drivers reject binaries they no longer understand by failing the link
+	var status int32
+	gl.GetProgramiv(program, gl.LINK_STATUS, &status)
+	if status == gl.FALSE {
+		DeleteProgram(program)
+		return 0, fmt.Errorf("driver rejected program binary")
+	}
+
+	return program, nil
+}
+
+// programBinary - The driver's binary of a linked program
+func programBinary(program uint32) (uint32, []byte, error) {
+	var length int32
+	gl.GetProgramiv(program, gl.PROGRAM_BINARY_LENGTH, &length)
+	if length <= 0 {
+		return 0, nil, fmt.Errorf("driver returned an empty program binary")
+	}
+
+	payload := make([]byte, length)
+	var format uint32
+	gl.GetProgramBinary(program, length, &length, &format, gl.Ptr(payload))
+	return format, payload[:length], nil
+}
@@ -0,0 +1,117 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestProgramBinaryRoundTrip(t *testing.T) {
+	payload := []byte("driver specific bytes")
+	format, decoded, err := decodeProgramBinary(encodeProgramBinary(0x8e21, payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != 0x8e21 || !bytes.Equal(decoded, payload) {
+		t.Errorf("decoded format 0x%x and payload %q, want 0x8e21 and %q", format, decoded, payload)
+	}
+}
+
+func TestDecodeProgramBinaryErrors(t *testing.T) {
+	valid := encodeProgramBinary(1, []byte("binary"))
+	headerSize := binary.Size(programBinaryHeader{})
+
+	corrupt := func(change func(data []byte) []byte) []byte {
+		return change(append([]byte(nil), valid...))
+	}
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"empty", nil, "failed to read header"},
+		{"short header", valid[:headerSize-1], "failed to read header"},
+		{"wrong magic", corrupt(func(d []byte) []byte { d[0] = 'X'; return d }), "unknown cache file format"},
+		{"wrong version", corrupt(func(d []byte) []byte { d[4]++; return d }), "unknown cache file format"},
+		{"truncated payload", valid[:len(valid)-1], "truncated program binary"},
+		{"trailing bytes", append(append([]byte(nil), valid...), 0), "truncated program binary"},
+		{"empty payload", encodeProgramBinary(1, nil), "truncated program binary"},
+		{"flipped payload byte", corrupt(func(d []byte) []byte { d[len(d)-1] ^= 1; return d }), "checksum mismatch"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := decodeProgramBinary(test.data)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("got error %v, want one containing %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestProgramCacheKey(t *testing.T) {
+	key := programCacheKey("driver 1", "vertex", "fragment")
+	if again := programCacheKey("driver 1", "vertex", "fragment"); again != key {
+		t.Errorf("same inputs gave keys %s and %s", key, again)
+	}
+
+	changed := map[string]string{
+		"driver":               programCacheKey("driver 2", "vertex", "fragment"),
+		"vertex source":        programCacheKey("driver 1", "vertex2", "fragment"),
+		"fragment source":      programCacheKey("driver 1", "vertex", "fragment2"),
+		"text between sources": programCacheKey("driver 1", "vertexf", "ragment"),
+	}
+	for what, other := range changed {
+		if other == key {
+			t.Errorf("changing the %s kept the key", what)
+		}
+	}
+}
+
+func TestProgramCacheLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "program_cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cache := NewProgramCache(dir)
+
+	old := cache.path(programCacheKey("driver 1", "vertex", "fragment"))
+	if err := cache.store(old, 7, []byte("linked")); err != nil {
+		t.Fatal(err)
+	}
+	format, payload, err := cache.load(old)
+	if err != nil || format != 7 || string(payload) != "linked" {
+		t.Fatalf("load returned %d, %q, %v", format, payload, err)
+	}
+
+	// After a driver update the key changes, the old binary is never offered to the new driver
+	// and the program is linked again
+	updated := cache.path(programCacheKey("driver 2", "vertex", "fragment"))
+	if _, _, err := cache.load(updated); !os.IsNotExist(err) {
+		t.Errorf("loading the binary of another driver returned %v, want a missing file", err)
+	}
+
+	// A damaged file is removed so the program is linked and stored again
+	if err := ioutil.WriteFile(old, []byte("GLPB garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cache.load(old); err == nil || os.IsNotExist(err) {
+		t.Errorf("loading a damaged file returned %v", err)
+	}
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Errorf("the damaged file was kept: %v", err)
+	}
+
+	if err := cache.store(old, 7, []byte("linked")); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cache.load(old); !os.IsNotExist(err) {
+		t.Errorf("loading after Clear returned %v", err)
+	}
+}
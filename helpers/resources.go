@@ -0,0 +1,218 @@
+package helpers
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/thegrandpackard/gogl/gl"
+)
+
+// Resource - A GL object created through one of the tracked wrappers below
+type Resource struct {
+	Kind string
+	ID   uint32
+
+	// Stack - The Go stack that created the object, when TrackStacks was set
+	Stack []byte
+
+	sequence uint64
+}
+
+func (r Resource) String() string {
+	return fmt.Sprintf("%s %d", r.Kind, r.ID)
+}
+
+// TrackStacks - Record the stack creating every object so leaks can be traced back to their origin
+var TrackStacks = true
+
+type resourceKey struct {
+	kind string
+	id   uint32
+}
+
+var resources = struct {
+	sync.Mutex
+	live     map[resourceKey]Resource
+	sequence uint64
+}{live: make(map[resourceKey]Resource)}
+
+func track(kind string, id uint32) {
+	if id == 0 {
+		return
+	}
+	r := Resource{Kind: kind, ID: id}
+	if TrackStacks {
+		r.Stack = debug.Stack()
+	}
+
+	resources.Lock()
+	defer resources.Unlock()
+	resources.sequence++
+	r.sequence = resources.sequence
+	resources.live[resourceKey{kind, id}] = r
+}
+
+func untrack(kind string, id uint32) {
+	if id == 0 {
+		return
+	}
+
+	resources.Lock()
+	defer resources.Unlock()
+	key := resourceKey{kind, id}
+	if _, ok := resources.live[key]; !ok {
+		log.Printf("deleting %s %d, which is not live: deleted twice or not created through helpers", kind, id)
+		return
+	}
+	delete(resources.live, key)
+}
+
+// GenBuffer - Creates a tracked buffer object
+func GenBuffer() uint32 {
+	var id uint32
+	gl.GenBuffers(1, &id)
+	track("buffer", id)
+	return id
+}
+
+// DeleteBuffer - Releases a buffer created by GenBuffer
+func DeleteBuffer(id uint32) {
+	untrack("buffer", id)
+	gl.DeleteBuffers(1, &id)
+}
+
+// GenVertexArray - Creates a tracked vertex array object
+func GenVertexArray() uint32 {
+	var id uint32
+	gl.GenVertexArrays(1, &id)
+	track("vertex array", id)
+	return id
+}
+
+// DeleteVertexArray - Releases a vertex array created by GenVertexArray
+func DeleteVertexArray(id uint32) {
+	untrack("vertex array", id)
+	gl.DeleteVertexArrays(1, &id)
+}
+
+// GenTexture - Creates a tracked texture object
+func GenTexture() uint32 {
+	var id uint32
+	gl.GenTextures(1, &id)
+	track("texture", id)
+	return id
+}
+
+// DeleteTexture - Releases a texture created by GenTexture or NewTexture
+func DeleteTexture(id uint32) {
+	untrack("texture", id)
+	gl.DeleteTextures(1, &id)
+}
+
+// GenFramebuffer - Creates a tracked framebuffer object
+func GenFramebuffer() uint32 {
+	var id uint32
+	gl.GenFramebuffers(1, &id)
+	track("framebuffer", id)
+	return id
+}
+
+// DeleteFramebuffer - Releases a framebuffer created by GenFramebuffer
+func DeleteFramebuffer(id uint32) {
+	untrack("framebuffer", id)
+	gl.DeleteFramebuffers(1, &id)
+}
+
+// GenRenderbuffer - Creates a tracked renderbuffer object
+func GenRenderbuffer() uint32 {
+	var id uint32
+	gl.GenRenderbuffers(1, &id)
+	track("renderbuffer", id)
+	return id
+}
+
+// DeleteRenderbuffer - Releases a renderbuffer created by GenRenderbuffer
+func DeleteRenderbuffer(id uint32) {
+	untrack("renderbuffer", id)
+	gl.DeleteRenderbuffers(1, &id)
+}
+
+// CreateProgram - Creates a tracked program object
+func CreateProgram() uint32 {
+	id := gl.CreateProgram()
+	track("program", id)
+	return id
+}
+
+// DeleteProgram - Releases a program created by CreateProgram, NewProgram or a ProgramCache
+func DeleteProgram(id uint32) {
+	untrack("program", id)
+	gl.DeleteProgram(id)
+}
+
+// CreateShader - Creates a tracked shader object
+func CreateShader(shaderType uint32) uint32 {
+	id := gl.CreateShader(shaderType)
+	track("shader", id)
+	return id
+}
+
+// DeleteShader - Releases a shader created by CreateShader or CompileShader
+func DeleteShader(id uint32) {
+	untrack("shader", id)
+	gl.DeleteShader(id)
+}
+
+// LiveResources - Returns the tracked objects that were not released yet, oldest first
+func LiveResources() []Resource {
+	resources.Lock()
+	defer resources.Unlock()
+
+	live := make([]Resource, 0, len(resources.live))
+	for _, r := range resources.live {
+		live = append(live, r)
+	}
+	sort.Slice(live, func(i, j int) bool { return live[i].sequence < live[j].sequence })
+	return live
+}
+
+// ReportLeaks - Logs every object that was not released yet along with the stack that created it,
+// and returns how many there were. Call it after shutting down, while the context still exists.
+func ReportLeaks() int {
+	live := LiveResources()
+	for _, r := range live {
+		if r.Stack != nil {
+			log.Printf("leaked %v, created at\n%s", r, r.Stack)
+		} else {
+			log.Printf("leaked %v", r)
+		}
+	}
+	return len(live)
+}
+
+// CheckLeaks - Returns an error naming every object that was not released yet, for harnesses that
+// should fail on leaks
+func CheckLeaks() error {
+	live := LiveResources()
+	if len(live) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(live))
+	for i, r := range live {
+		names[i] = r.String()
+	}
+	return fmt.Errorf("%d GL object(s) leaked: %s", len(live), strings.Join(names, ", "))
+}
+
+// ForgetResources - Stops tracking every live object without releasing it, so later checks only
+// see objects created from now on
+func ForgetResources() {
+	resources.Lock()
+	defer resources.Unlock()
+	resources.live = make(map[resourceKey]Resource)
+}
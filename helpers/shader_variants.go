@@ -0,0 +1,193 @@
+package helpers
+
+import (
+	"sort"
+	"strings"
+)
+
+// Feature keywords understood by BaseVertexShader and BaseFragmentShader
+const (
+	Textured    = "TEXTURED"
+	VertexColor = "VERTEX_COLOR"
+	Skinned     = "SKINNED"
+
+	// MatrixBlock reads projection and camera from the Matrices uniform block, see MatrixUniforms
+	MatrixBlock = "MATRIX_BLOCK"
+)
+
+// ShaderVariants - Compiles permutations of one base shader on demand and keeps one program per keyword set
+type ShaderVariants struct {
+	VertexSource   string
+	FragmentSource string
+
+	// Cache is optional, when set variants are loaded from and stored to disk
+	Cache *ProgramCache
+
+	programs map[string]uint32
+}
+
+// NewShaderVariants - Returns an empty variant set for the given base sources
+func NewShaderVariants(vertexSource, fragmentSource string) *ShaderVariants {
+	return &ShaderVariants{
+		VertexSource:   vertexSource,
+		FragmentSource: fragmentSource,
+		programs:       make(map[string]uint32),
+	}
+}
+
+// VariantKey - Returns the canonical key of a keyword set, independent of order and duplicates
+func VariantKey(keywords ...string) string {
+	return strings.Join(normalizeKeywords(keywords), "+")
+}
+
+// Program - Returns the program for the keyword set, compiling it the first time it is requested
+func (v *ShaderVariants) Program(keywords ...string) (uint32, error) {
+	keywords = normalizeKeywords(keywords)
+	key := strings.Join(keywords, "+")
+	if program, ok := v.programs[key]; ok {
+		return program, nil
+	}
+
+	var program uint32
+	var err error
+	if v.Cache != nil {
+		program, err = v.Cache.NewProgram(v.VertexSource, v.FragmentSource, keywords...)
+	} else {
+		program, err = NewProgram(InjectDefines(v.VertexSource, keywords), InjectDefines(v.FragmentSource, keywords))
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	v.programs[key] = program
+	return program, nil
+}
+
+// Get - Returns an already compiled variant by key without compiling, for use at draw time
+func (v *ShaderVariants) Get(key string) (uint32, bool) {
+	program, ok := v.programs[key]
+	return program, ok
+}
+
+// Keys - Returns the keys of all compiled variants
+func (v *ShaderVariants) Keys() []string {
+	keys := make([]string, 0, len(v.programs))
+	for key := range v.programs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Delete - Releases every compiled variant
+func (v *ShaderVariants) Delete() {
+	for key, program := range v.programs {
+		DeleteProgram(program)
+		delete(v.programs, key)
+	}
+}
+
+func normalizeKeywords(keywords []string) []string {
+	normalized := make([]string, 0, len(keywords))
+	seen := make(map[string]bool)
+	for _, keyword := range keywords {
+		keyword = strings.TrimSpace(keyword)
+		if keyword == "" || seen[keyword] {
+			continue
+		}
+		seen[keyword] = true
+		normalized = append(normalized, keyword)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// BaseVertexShader - Vertex shader shared by the cube demos. Attribute locations are fixed so
+// every variant can be drawn from the same vertex array.
+var BaseVertexShader = `
+#version 330
+
+#ifdef MATRIX_BLOCK
+layout(std140) uniform Matrices {
+    mat4 projection;
+    mat4 camera;
+};
+#else
+uniform mat4 projection;
+uniform mat4 camera;
+#endif
+uniform mat4 model;
+
+layout(location = 0) in vec3 vert;
+
+#ifdef VERTEX_COLOR
+layout(location = 1) in vec3 color;
+out vec3 fragmentColor;
+#endif
+
+#ifdef TEXTURED
+layout(location = 2) in vec2 vertTexCoord;
+out vec2 fragTexCoord;
+#endif
+
+#ifdef SKINNED
+#ifndef MAX_BONES
+#define MAX_BONES 64
+#endif
+uniform mat4 bones[MAX_BONES];
+layout(location = 3) in ivec4 boneIndices;
+layout(location = 4) in vec4 boneWeights;
+#endif
+
+void main() {
+    vec4 position = vec4(vert, 1);
+
+#ifdef SKINNED
+    mat4 skin = boneWeights.x * bones[boneIndices.x] +
+                boneWeights.y * bones[boneIndices.y] +
+                boneWeights.z * bones[boneIndices.z] +
+                boneWeights.w * bones[boneIndices.w];
+    position = skin * position;
+#endif
+
+#ifdef VERTEX_COLOR
+    fragmentColor = color;
+#endif
+
+#ifdef TEXTURED
+    fragTexCoord = vertTexCoord;
+#endif
+
+    gl_Position = projection * camera * model * position;
+}
+` + "\x00"
+
+// BaseFragmentShader - Fragment shader matching BaseVertexShader
+var BaseFragmentShader = `
+#version 330
+
+#ifdef VERTEX_COLOR
+in vec3 fragmentColor;
+#endif
+
+#ifdef TEXTURED
+uniform sampler2D tex;
+in vec2 fragTexCoord;
+#endif
+
+out vec4 outputColor;
+
+void main() {
+    vec4 color = vec4(1);
+
+#ifdef VERTEX_COLOR
+    color.rgb *= fragmentColor;
+#endif
+
+#ifdef TEXTURED
+    color *= texture(tex, fragTexCoord);
+#endif
+
+    outputColor = color;
+}
+` + "\x00"
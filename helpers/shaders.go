@@ -4,25 +4,43 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/go-gl/gl/v2.1/gl"
+	"github.com/thegrandpackard/gogl/gl"
 )
 
 func NewProgram(vertexShaderSource, fragmentShaderSource string) (uint32, error) {
+	return linkProgram(vertexShaderSource, fragmentShaderSource, false)
+}
+
+// linkProgram - Compiles and links a program, optionally asking the driver to keep the binary retrievable
+func linkProgram(vertexShaderSource, fragmentShaderSource string, retrievable bool) (uint32, error) {
 
-	vertexShader, err := compileShader(vertexShaderSource, gl.VERTEX_SHADER)
+	vertexShader, err := CompileShader(vertexShaderSource, gl.VERTEX_SHADER)
 	if err != nil {
 		return 0, err
 	}
 
-	fragmentShader, err := compileShader(fragmentShaderSource, gl.FRAGMENT_SHADER)
+	fragmentShader, err := CompileShader(fragmentShaderSource, gl.FRAGMENT_SHADER)
 	if err != nil {
+		DeleteShader(vertexShader)
 		return 0, err
 	}
 
-	program := gl.CreateProgram()
+	// The shaders are only flagged for deletion while attached and go away with the program
+	defer DeleteShader(vertexShader)
+	defer DeleteShader(fragmentShader)
+
+	program := CreateProgram()
 
 	gl.AttachShader(program, vertexShader)
 	gl.AttachShader(program, fragmentShader)
+
+	// Explicit locations are stripped from GLSL 1.20 translations, so bind them before linking
+	for name, location := range attribLocations(vertexShaderSource) {
+		gl.BindAttribLocation(program, location, gl.Str(name+"\x00"))
+	}
+	if retrievable {
+		gl.ProgramParameteri(program, gl.PROGRAM_BINARY_RETRIEVABLE_HINT, gl.TRUE)
+	}
 	gl.LinkProgram(program)
 
 	var status int32
@@ -34,18 +52,17 @@ func NewProgram(vertexShaderSource, fragmentShaderSource string) (uint32, error)
 		log := strings.Repeat("\x00", int(logLength+1))
 		gl.GetProgramInfoLog(program, logLength, nil, gl.Str(log))
 
+		DeleteProgram(program)
 		return 0, fmt.Errorf("failed to link program: %v", log)
 	}
 
-	gl.DeleteShader(vertexShader)
-	gl.DeleteShader(fragmentShader)
-
 	return program, nil
 }
 
 func CompileShader(source string, shaderType uint32) (uint32, error) {
-	shader := gl.CreateShader(shaderType)
+	shader := CreateShader(shaderType)
 
+	source = PrepareShader(source, shaderType)
 	csources, free := gl.Strs(source)
 	gl.ShaderSource(shader, 1, csources, nil)
 	free()
@@ -60,8 +77,47 @@ func CompileShader(source string, shaderType uint32) (uint32, error) {
 		log := strings.Repeat("\x00", int(logLength+1))
 		gl.GetShaderInfoLog(shader, logLength, nil, gl.Str(log))
 
+		DeleteShader(shader)
 		return 0, fmt.Errorf("failed to compile %v: %v", source, log)
 	}
 
 	return shader, nil
 }
+
+// InjectDefines - Returns source with a #define line for each entry inserted after the #version directive.
+// Entries are either "NAME" or "NAME=VALUE".
+func InjectDefines(source string, defines []string) string {
+	if len(defines) == 0 {
+		return source
+	}
+
+	var block strings.Builder
+	for _, define := range defines {
+		name, value := define, ""
+		if i := strings.Index(define, "="); i >= 0 {
+			name, value = define[:i], define[i+1:]
+		}
+		block.WriteString("#define " + name)
+		if value != "" {
+			block.WriteString(" " + value)
+		}
+		block.WriteString("\n")
+	}
+
+	// The #version directive has to stay the first statement of the shader
+	start := strings.Index(source, "#version")
+	if start < 0 {
+		return block.String() + source
+	}
+	end := strings.Index(source[start:], "\n")
+	if end < 0 {
+		terminator := ""
+		if strings.HasSuffix(source, "\x00") {
+			source, terminator = strings.TrimSuffix(source, "\x00"), "\x00"
+		}
+		return source + "\n" + block.String() + terminator
+	}
+	end += start + 1
+
+	return source[:end] + block.String() + source[end:]
+}
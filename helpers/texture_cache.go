@@ -0,0 +1,69 @@
+package helpers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// TextureCache - Keeps textures loaded for as long as the cache lives, so scenes coming and
+// going do not decode and upload the same images again. Files with identical contents share
+// one texture, wherever they are.
+type TextureCache struct {
+	byPath    map[string]uint32
+	byContent map[[sha256.Size]byte]uint32
+}
+
+// NewTextureCache - Returns an empty cache
+func NewTextureCache() *TextureCache {
+	return &TextureCache{
+		byPath:    make(map[string]uint32),
+		byContent: make(map[[sha256.Size]byte]uint32),
+	}
+}
+
+// Texture - Returns the texture of file, loading it the first time. The cache owns the texture,
+// callers must not delete it.
+func (c *TextureCache) Texture(file string) (uint32, error) {
+	path, err := filepath.Abs(file)
+	if err != nil {
+		path = filepath.Clean(file)
+	}
+	if texture, ok := c.byPath[path]; ok {
+		return texture, nil
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return 0, fmt.Errorf("texture %q not found on disk: %v", file, err)
+	}
+
+	sum := sha256.Sum256(data)
+	texture, ok := c.byContent[sum]
+	if !ok {
+		if texture, err = newTexture(bytes.NewReader(data)); err != nil {
+			return 0, fmt.Errorf("failed to load texture %q: %v", file, err)
+		}
+		c.byContent[sum] = texture
+	}
+	c.byPath[path] = texture
+	return texture, nil
+}
+
+// Len - Returns how many textures are loaded
+func (c *TextureCache) Len() int {
+	return len(c.byContent)
+}
+
+// Delete - Releases every texture of the cache
+func (c *TextureCache) Delete() {
+	for sum, texture := range c.byContent {
+		DeleteTexture(texture)
+		delete(c.byContent, sum)
+	}
+	for path := range c.byPath {
+		delete(c.byPath, path)
+	}
+}
@@ -4,9 +4,10 @@ import (
 	"fmt"
 	"image"
 	"image/draw"
+	"io"
 	"os"
 
-	"github.com/go-gl/gl/v2.1/gl"
+	"github.com/thegrandpackard/gogl/gl"
 )
 
 func NewTexture(file string) (uint32, error) {
@@ -14,7 +15,13 @@ func NewTexture(file string) (uint32, error) {
 	if err != nil {
 		return 0, fmt.Errorf("texture %q not found on disk: %v", file, err)
 	}
-	img, _, err := image.Decode(imgFile)
+	defer imgFile.Close()
+
+	return newTexture(imgFile)
+}
+
+func newTexture(r io.Reader) (uint32, error) {
+	img, _, err := image.Decode(r)
 	if err != nil {
 		return 0, err
 	}
@@ -25,8 +32,7 @@ func NewTexture(file string) (uint32, error) {
 	}
 	draw.Draw(rgba, rgba.Bounds(), img, image.Point{0, 0}, draw.Src)
 
-	var texture uint32
-	gl.GenTextures(1, &texture)
+	texture := GenTexture()
 	gl.ActiveTexture(gl.TEXTURE0)
 	gl.BindTexture(gl.TEXTURE_2D, texture)
 	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"fmt"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/thegrandpackard/gogl/gl"
+)
+
+// Tile - A rectangle of a larger image, in pixels from its top left, rendered on its own when the
+// image is too large for one framebuffer
+type Tile struct {
+	X, Y, Width, Height int
+
+	// ImageWidth, ImageHeight - Size of the whole image
+	ImageWidth, ImageHeight int
+}
+
+// Tiles - Splits an image into tiles at most size pixels wide and high, row by row from the top
+// left. The last row and column take what is left over.
+func Tiles(width, height, size int) ([]Tile, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("cannot tile a %dx%d image", width, height)
+	}
+	if size <= 0 {
+		return nil, fmt.Errorf("tile size must be positive, got %d", size)
+	}
+
+	var tiles []Tile
+	for y := 0; y < height; y += size {
+		for x := 0; x < width; x += size {
+			tile := Tile{X: x, Y: y, Width: size, Height: size, ImageWidth: width, ImageHeight: height}
+			if x+size > width {
+				tile.Width = width - x
+			}
+			if y+size > height {
+				tile.Height = height - y
+			}
+			tiles = append(tiles, tile)
+		}
+	}
+	return tiles, nil
+}
+
+// Projection - Narrows projection, made for the whole image, to the off-center frustum of the
+// tile. Clip space is scaled and shifted so the tile fills the viewport; the tile's pixels
+// keep the exact centers they have in the whole image, so neighbouring tiles meet without seams.
+func (t Tile) Projection(projection mgl32.Mat4) mgl32.Mat4 {
+	// The tile in normalized device coordinates of the whole image, whose y points up
+	left := 2*float64(t.X)/float64(t.ImageWidth) - 1
+	right := 2*float64(t.X+t.Width)/float64(t.ImageWidth) - 1
+	top := 1 - 2*float64(t.Y)/float64(t.ImageHeight)
+	bottom := 1 - 2*float64(t.Y+t.Height)/float64(t.ImageHeight)
+
+	narrow := mgl32.Ident4()
+	narrow.Set(0, 0, float32(2/(right-left)))
+	narrow.Set(0, 3, float32(-(right+left)/(right-left)))
+	narrow.Set(1, 1, float32(2/(top-bottom)))
+	narrow.Set(1, 3, float32(-(top+bottom)/(top-bottom)))
+	return narrow.Mul4(projection)
+}
+
+// MaxTileSize - The largest tile the current context can render, limited by its viewport and
+// renderbuffer sizes
+func MaxTileSize() int {
+	var viewport [2]int32
+	var renderbuffer int32
+	gl.GetIntegerv(gl.MAX_VIEWPORT_DIMS, &viewport[0])
+	gl.GetIntegerv(gl.MAX_RENDERBUFFER_SIZE, &renderbuffer)
+
+	size := renderbuffer
+	for _, dim := range viewport {
+		if dim < size {
+			size = dim
+		}
+	}
+	return int(size)
+}
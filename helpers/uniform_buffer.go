@@ -0,0 +1,165 @@
+package helpers
+
+import (
+	"fmt"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/thegrandpackard/gogl/gl"
+	"github.com/thegrandpackard/gogl/layout"
+)
+
+// UniformBuffer - A uniform buffer object holding one Go struct encoded in std140
+type UniformBuffer struct {
+	ID      uint32
+	Binding uint32
+	Size    int
+
+	data []byte
+}
+
+// NewUniformBuffer - Returns a uniform buffer sized for block and attached to the given binding point
+func NewUniformBuffer(binding uint32, block interface{}) (*UniformBuffer, error) {
+	size, err := layout.Size(layout.Std140, block)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := &UniformBuffer{Binding: binding, Size: size, data: make([]byte, size)}
+	buffer.ID = GenBuffer()
+	gl.BindBuffer(gl.UNIFORM_BUFFER, buffer.ID)
+	gl.BufferData(gl.UNIFORM_BUFFER, size, nil, gl.DYNAMIC_DRAW)
+	gl.BindBuffer(gl.UNIFORM_BUFFER, 0)
+
+	if err := buffer.Update(block); err != nil {
+		buffer.Delete()
+		return nil, err
+	}
+	buffer.Bind()
+
+	return buffer, nil
+}
+
+// Update - Encodes block and uploads it to the buffer
+func (b *UniformBuffer) Update(block interface{}) error {
+	if err := layout.EncodeInto(layout.Std140, b.data, block); err != nil {
+		return err
+	}
+	gl.BindBuffer(gl.UNIFORM_BUFFER, b.ID)
+	gl.BufferSubData(gl.UNIFORM_BUFFER, 0, b.Size, gl.Ptr(b.data))
+	gl.BindBuffer(gl.UNIFORM_BUFFER, 0)
+	return nil
+}
+
+// Bind - Attaches the buffer to its binding point
+func (b *UniformBuffer) Bind() {
+	gl.BindBufferBase(gl.UNIFORM_BUFFER, b.Binding, b.ID)
+}
+
+// Delete - Releases the buffer
+func (b *UniformBuffer) Delete() {
+	DeleteBuffer(b.ID)
+	b.ID = 0
+}
+
+// BindUniformBlock - Points the named uniform block of program at a binding point
+func BindUniformBlock(program uint32, name string, binding uint32) error {
+	index := gl.GetUniformBlockIndex(program, gl.Str(name+"\x00"))
+	if index == gl.INVALID_INDEX {
+		return fmt.Errorf("uniform block %q not found in program %d", name, program)
+	}
+	gl.UniformBlockBinding(program, index, binding)
+	return nil
+}
+
+// CheckUniformBlockSize - Verifies that the size the driver reports for a uniform block matches the Go struct
+func CheckUniformBlockSize(program uint32, name string, block interface{}) error {
+	index := gl.GetUniformBlockIndex(program, gl.Str(name+"\x00"))
+	if index == gl.INVALID_INDEX {
+		return fmt.Errorf("uniform block %q not found in program %d", name, program)
+	}
+
+	var driverSize int32
+	gl.GetActiveUniformBlockiv(program, index, gl.UNIFORM_BLOCK_DATA_SIZE, &driverSize)
+
+	size, err := layout.Size(layout.Std140, block)
+	if err != nil {
+		return err
+	}
+	if int(driverSize) != size {
+		return fmt.Errorf("uniform block %q is %d bytes in the shader but %d bytes in %T", name, driverSize, size, block)
+	}
+	return nil
+}
+
+// Matrices - The Matrices uniform block of BaseVertexShader, the transforms every object of a
+// frame shares
+type Matrices struct {
+	Projection mgl32.Mat4
+	Camera     mgl32.Mat4
+}
+
+// MatricesBinding - The binding point of the Matrices block. Only one scene renders at a time,
+// so they all share it.
+const MatricesBinding = 0
+
+// MatrixBlockSupported - Whether the context can read the Matrices block. GLSL 1.20 has no
+// uniform blocks, so shaders PrepareShader translates to it cannot either.
+func MatrixBlockSupported() bool {
+	return Caps.UniformBuffers && Caps.GLSLVersion >= 330
+}
+
+// MatrixUniforms - Sets the projection and camera matrices of a BaseVertexShader program, through
+// a Matrices uniform buffer when the program was built with MatrixBlock and as plain uniforms
+// otherwise
+type MatrixUniforms struct {
+	buffer *UniformBuffer
+
+	projection, camera int32
+}
+
+// NewMatrixUniforms - Returns the matrix uniforms of program
+func NewMatrixUniforms(program uint32) (*MatrixUniforms, error) {
+	m := &MatrixUniforms{
+		projection: gl.GetUniformLocation(program, gl.Str("projection\x00")),
+		camera:     gl.GetUniformLocation(program, gl.Str("camera\x00")),
+	}
+	if !Caps.UniformBuffers || gl.GetUniformBlockIndex(program, gl.Str("Matrices\x00")) == gl.INVALID_INDEX {
+		return m, nil
+	}
+
+	if err := CheckUniformBlockSize(program, "Matrices", Matrices{}); err != nil {
+		return nil, err
+	}
+	if err := BindUniformBlock(program, "Matrices", MatricesBinding); err != nil {
+		return nil, err
+	}
+	buffer, err := NewUniformBuffer(MatricesBinding, Matrices{})
+	if err != nil {
+		return nil, err
+	}
+	m.buffer = buffer
+	return m, nil
+}
+
+// Set - Sets the matrices of the next draw calls, the program must be in use when they are
+// plain uniforms
+func (m *MatrixUniforms) Set(projection, camera mgl32.Mat4) {
+	if m.buffer == nil {
+		gl.UniformMatrix4fv(m.projection, 1, false, &projection[0])
+		gl.UniformMatrix4fv(m.camera, 1, false, &camera[0])
+		return
+	}
+
+	// Matrices was laid out once already by NewUniformBuffer, so encoding it cannot fail
+	m.buffer.Update(Matrices{Projection: projection, Camera: camera})
+	m.buffer.Bind()
+}
+
+// Delete - Releases the uniform buffer, if there is one. Nil does nothing, for scenes that
+// failed to initialize.
+func (m *MatrixUniforms) Delete() {
+	if m != nil && m.buffer != nil {
+		m.buffer.Delete()
+		m.buffer = nil
+	}
+}
@@ -0,0 +1,380 @@
+// Package layout encodes Go structs into the std140 and std430 memory layouts used by
+// GLSL uniform and shader storage blocks.
+//
+// Fields map to GLSL types by their Go type:
+//
+//	float32, int32, uint32, bool       float, int, uint, bool
+//	mgl32.Vec2, Vec3, Vec4             vec2, vec3, vec4
+//	mgl32.Mat2 ... Mat4, Mat2x3, ...   the matching column major matrix
+//	[N]T                               T[N]
+//	struct                             nested struct
+//
+// A `glsl:"name"` tag sets the member name used by Offsetof, `glsl:"-"` skips the field and
+// `glsl:",vector"` lays out a [2..4] array of scalars as a vector (ivec3, uvec2, ...) instead of an array.
+package layout
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Layout - A GLSL block memory layout
+type Layout int
+
+const (
+	Std140 Layout = iota
+	Std430
+)
+
+func (l Layout) String() string {
+	switch l {
+	case Std140:
+		return "std140"
+	case Std430:
+		return "std430"
+	}
+	return fmt.Sprintf("Layout(%d)", int(l))
+}
+
+type kind int
+
+const (
+	scalarKind kind = iota
+	vectorKind
+	matrixKind
+	arrayKind
+	structKind
+)
+
+// member - A laid out struct field
+type member struct {
+	name   string
+	index  int
+	offset int
+	info   *typeInfo
+}
+
+// typeInfo - The computed layout of a single Go type
+type typeInfo struct {
+	kind   kind
+	align  int
+	size   int
+	stride int // element stride of arrays, column stride of matrices
+	count  int // array length, vector components or matrix columns
+	elem   *typeInfo
+	scalar reflect.Kind
+	fields []member
+}
+
+type cacheKey struct {
+	layout Layout
+	t      reflect.Type
+}
+
+var cache sync.Map
+
+// matrix shapes of the mathgl types as GLSL columns x rows
+var matrices = map[reflect.Type][2]int{
+	reflect.TypeOf(mgl32.Mat2{}):   {2, 2},
+	reflect.TypeOf(mgl32.Mat3{}):   {3, 3},
+	reflect.TypeOf(mgl32.Mat4{}):   {4, 4},
+	reflect.TypeOf(mgl32.Mat2x3{}): {3, 2},
+	reflect.TypeOf(mgl32.Mat2x4{}): {4, 2},
+	reflect.TypeOf(mgl32.Mat3x2{}): {2, 3},
+	reflect.TypeOf(mgl32.Mat3x4{}): {4, 3},
+	reflect.TypeOf(mgl32.Mat4x2{}): {2, 4},
+	reflect.TypeOf(mgl32.Mat4x3{}): {3, 4},
+}
+
+var vectors = map[reflect.Type]bool{
+	reflect.TypeOf(mgl32.Vec2{}): true,
+	reflect.TypeOf(mgl32.Vec3{}): true,
+	reflect.TypeOf(mgl32.Vec4{}): true,
+}
+
+// Size - Returns the number of bytes v occupies in layout l
+func Size(l Layout, v interface{}) (int, error) {
+	info, err := l.info(reflect.TypeOf(v))
+	if err != nil {
+		return 0, err
+	}
+	return info.size, nil
+}
+
+// Offsetof - Returns the byte offset of a member of the struct v. Nested members and array
+// elements are addressed with a GLSL style path such as "lights[2].position".
+func Offsetof(l Layout, v interface{}, path string) (int, error) {
+	info, err := l.info(reflect.TypeOf(v))
+	if err != nil {
+		return 0, err
+	}
+
+	offset := 0
+	for _, part := range strings.Split(path, ".") {
+		name, index := part, -1
+		if i := strings.Index(part, "["); i >= 0 && strings.HasSuffix(part, "]") {
+			if _, err := fmt.Sscanf(part[i:], "[%d]", &index); err != nil {
+				return 0, fmt.Errorf("invalid array index in %q", path)
+			}
+			name = part[:i]
+		}
+
+		if info.kind != structKind {
+			return 0, fmt.Errorf("%q is not a struct member in %q", name, path)
+		}
+		found := false
+		for _, field := range info.fields {
+			if field.name == name {
+				offset += field.offset
+				info = field.info
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("no member %q in %q", name, path)
+		}
+
+		if index >= 0 {
+			if info.kind != arrayKind && info.kind != matrixKind {
+				return 0, fmt.Errorf("member %q in %q is not an array", name, path)
+			}
+			if index >= info.count {
+				return 0, fmt.Errorf("index %d out of range for %q", index, path)
+			}
+			offset += index * info.stride
+			info = info.elem
+		}
+	}
+	return offset, nil
+}
+
+// Encode - Returns v laid out in layout l
+func Encode(l Layout, v interface{}) ([]byte, error) {
+	info, err := l.info(reflect.TypeOf(v))
+	if err != nil {
+		return nil, err
+	}
+	value, err := indirect(v)
+	if err != nil {
+		return nil, err
+	}
+	buffer := make([]byte, info.size)
+	encode(buffer, 0, info, value)
+	return buffer, nil
+}
+
+// EncodeInto - Lays out v into dst, which must be at least Size bytes long. Padding bytes are left untouched.
+func EncodeInto(l Layout, dst []byte, v interface{}) error {
+	info, err := l.info(reflect.TypeOf(v))
+	if err != nil {
+		return err
+	}
+	if len(dst) < info.size {
+		return fmt.Errorf("buffer of %d bytes too small for %d byte %v block", len(dst), info.size, l)
+	}
+	value, err := indirect(v)
+	if err != nil {
+		return err
+	}
+	encode(dst, 0, info, value)
+	return nil
+}
+
+func (l Layout) info(t reflect.Type) (*typeInfo, error) {
+	if t == nil {
+		return nil, fmt.Errorf("cannot lay out nil")
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%v blocks must be structs, got %v", l, t)
+	}
+	key := cacheKey{l, t}
+	if info, ok := cache.Load(key); ok {
+		return info.(*typeInfo), nil
+	}
+	info, err := l.typeInfo(t, false)
+	if err != nil {
+		return nil, err
+	}
+	cache.Store(key, info)
+	return info, nil
+}
+
+func (l Layout) typeInfo(t reflect.Type, vector bool) (*typeInfo, error) {
+	if shape, ok := matrices[t]; ok {
+		column, err := l.vectorInfo(reflect.Float32, shape[1])
+		if err != nil {
+			return nil, err
+		}
+		info := l.array(column, shape[0])
+		info.kind = matrixKind
+		return info, nil
+	}
+	if vectors[t] || (vector && t.Kind() == reflect.Array) {
+		return l.vectorInfo(t.Elem().Kind(), t.Len())
+	}
+
+	switch t.Kind() {
+	case reflect.Float32, reflect.Int32, reflect.Uint32, reflect.Bool:
+		return &typeInfo{kind: scalarKind, align: 4, size: 4, scalar: t.Kind()}, nil
+
+	case reflect.Array:
+		elem, err := l.typeInfo(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		return l.array(elem, t.Len()), nil
+
+	case reflect.Struct:
+		info := &typeInfo{kind: structKind, align: 4}
+		offset := 0
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, options := parseTag(field)
+			if name == "-" {
+				continue
+			}
+			if field.PkgPath != "" {
+				return nil, fmt.Errorf("unexported field %s.%s must be tagged glsl:\"-\"", t.Name(), field.Name)
+			}
+			fieldInfo, err := l.typeInfo(field.Type, options == "vector")
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %v", t.Name(), field.Name, err)
+			}
+			offset = roundUp(offset, fieldInfo.align)
+			info.fields = append(info.fields, member{name: name, index: i, offset: offset, info: fieldInfo})
+			offset += fieldInfo.size
+			if fieldInfo.align > info.align {
+				info.align = fieldInfo.align
+			}
+		}
+		if l == Std140 {
+			info.align = roundUp(info.align, 16)
+		}
+		info.size = roundUp(offset, info.align)
+		return info, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %v", t)
+}
+
+func (l Layout) vectorInfo(scalar reflect.Kind, components int) (*typeInfo, error) {
+	switch scalar {
+	case reflect.Float32, reflect.Int32, reflect.Uint32, reflect.Bool:
+	default:
+		return nil, fmt.Errorf("unsupported vector component type %v", scalar)
+	}
+	if components < 2 || components > 4 {
+		return nil, fmt.Errorf("vectors need 2 to 4 components, got %d", components)
+	}
+	align := 16
+	if components == 2 {
+		align = 8
+	}
+	return &typeInfo{
+		kind:   vectorKind,
+		align:  align,
+		size:   4 * components,
+		stride: 4,
+		count:  components,
+		scalar: scalar,
+		elem:   &typeInfo{kind: scalarKind, align: 4, size: 4, scalar: scalar},
+	}, nil
+}
+
+// array - Lays out count elements of elem. std140 rounds the element stride and the array
+// alignment up to a vec4, std430 only to the element alignment.
+func (l Layout) array(elem *typeInfo, count int) *typeInfo {
+	align := elem.align
+	if l == Std140 {
+		align = roundUp(align, 16)
+	}
+	stride := roundUp(elem.size, align)
+	return &typeInfo{
+		kind:   arrayKind,
+		align:  align,
+		size:   stride * count,
+		stride: stride,
+		count:  count,
+		elem:   elem,
+	}
+}
+
+func encode(buffer []byte, offset int, info *typeInfo, v reflect.Value) {
+	switch info.kind {
+	case scalarKind:
+		putScalar(buffer[offset:], v)
+	case vectorKind, matrixKind, arrayKind:
+		if info.kind == matrixKind {
+			// mathgl stores matrices column major, so column i starts at element i*rows
+			rows := info.elem.count
+			for column := 0; column < info.count; column++ {
+				for row := 0; row < rows; row++ {
+					putScalar(buffer[offset+column*info.stride+row*4:], v.Index(column*rows+row))
+				}
+			}
+			return
+		}
+		for i := 0; i < info.count; i++ {
+			encode(buffer, offset+i*info.stride, info.elem, v.Index(i))
+		}
+	case structKind:
+		for _, field := range info.fields {
+			encode(buffer, offset+field.offset, field.info, v.Field(field.index))
+		}
+	}
+}
+
+// indirect - The struct v points to. A nil pointer has a type to lay out but nothing to encode.
+func indirect(v interface{}) (reflect.Value, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return reflect.Value{}, fmt.Errorf("cannot encode nil %v", value.Type())
+		}
+		value = value.Elem()
+	}
+	return value, nil
+}
+
+func putScalar(buffer []byte, v reflect.Value) {
+	var bits uint32
+	switch v.Kind() {
+	case reflect.Float32:
+		bits = math.Float32bits(float32(v.Float()))
+	case reflect.Int32:
+		bits = uint32(int32(v.Int()))
+	case reflect.Uint32:
+		bits = uint32(v.Uint())
+	case reflect.Bool:
+		if v.Bool() {
+			bits = 1
+		}
+	}
+	binary.LittleEndian.PutUint32(buffer, bits)
+}
+
+func parseTag(field reflect.StructField) (name, options string) {
+	tag := field.Tag.Get("glsl")
+	name = tag
+	if i := strings.Index(tag, ","); i >= 0 {
+		name, options = tag[:i], tag[i+1:]
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, options
+}
+
+func roundUp(n, align int) int {
+	return (n + align - 1) / align * align
+}
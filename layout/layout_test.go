@@ -0,0 +1,221 @@
+package layout
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+type vec3Float struct {
+	Direction mgl32.Vec3
+	Intensity float32
+}
+
+type floatArray struct {
+	A      float32
+	Values [3]float32
+	B      float32
+}
+
+type vec2Array struct {
+	Points [2]mgl32.Vec2
+}
+
+type vec3Array struct {
+	Points [2]mgl32.Vec3
+	After  float32
+}
+
+type mat3Block struct {
+	Normal mgl32.Mat3
+	After  float32
+}
+
+type mat2Block struct {
+	M mgl32.Mat2
+}
+
+type scalarStruct struct {
+	X float32
+}
+
+type nestedScalar struct {
+	A     float32
+	Inner scalarStruct
+	B     float32
+}
+
+type vec2Struct struct {
+	V mgl32.Vec2
+	F float32
+}
+
+type nestedVec2 struct {
+	A     float32
+	Inner vec2Struct `glsl:"inner"`
+	B     float32
+}
+
+type ivec3Block struct {
+	A     [3]int32 `glsl:",vector"`
+	After float32
+}
+
+type skipped struct {
+	A      float32
+	hidden int `glsl:"-"`
+	B      float32
+}
+
+func TestSizeAndOffsets(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  Layout
+		block   interface{}
+		size    int
+		offsets map[string]int
+	}{
+		// A vec3 leaves room for a scalar in its last four bytes
+		{"vec3 float std140", Std140, vec3Float{}, 16, map[string]int{"Direction": 0, "Intensity": 12}},
+		{"vec3 float std430", Std430, vec3Float{}, 16, map[string]int{"Direction": 0, "Intensity": 12}},
+
+		// Scalar arrays have a vec4 stride in std140 only
+		{"float array std140", Std140, floatArray{}, 80, map[string]int{"A": 0, "Values": 16, "Values[1]": 32, "Values[2]": 48, "B": 64}},
+		{"float array std430", Std430, floatArray{}, 20, map[string]int{"A": 0, "Values": 4, "Values[1]": 8, "Values[2]": 12, "B": 16}},
+		{"vec2 array std140", Std140, vec2Array{}, 32, map[string]int{"Points[1]": 16}},
+		{"vec2 array std430", Std430, vec2Array{}, 16, map[string]int{"Points[1]": 8}},
+
+		// vec3 elements are aligned to 16 bytes in both
+		{"vec3 array std140", Std140, vec3Array{}, 48, map[string]int{"Points[1]": 16, "After": 32}},
+		{"vec3 array std430", Std430, vec3Array{}, 48, map[string]int{"Points[1]": 16, "After": 32}},
+
+		// Matrices are arrays of column vectors
+		{"mat3 std140", Std140, mat3Block{}, 64, map[string]int{"Normal[0]": 0, "Normal[1]": 16, "Normal[2]": 32, "After": 48}},
+		{"mat3 std430", Std430, mat3Block{}, 64, map[string]int{"Normal[1]": 16, "Normal[2]": 32, "After": 48}},
+		{"mat2 std140", Std140, mat2Block{}, 32, map[string]int{"M[1]": 16}},
+		{"mat2 std430", Std430, mat2Block{}, 16, map[string]int{"M[1]": 8}},
+
+		// std140 rounds the alignment and size of structs up to a vec4
+		{"nested scalar std140", Std140, nestedScalar{}, 48, map[string]int{"Inner": 16, "Inner.X": 16, "B": 32}},
+		{"nested scalar std430", Std430, nestedScalar{}, 12, map[string]int{"Inner": 4, "Inner.X": 4, "B": 8}},
+		{"nested vec2 std140", Std140, nestedVec2{}, 48, map[string]int{"inner": 16, "inner.F": 24, "B": 32}},
+		{"nested vec2 std430", Std430, nestedVec2{}, 32, map[string]int{"inner": 8, "inner.F": 16, "B": 24}},
+
+		{"ivec3 std140", Std140, ivec3Block{}, 16, map[string]int{"A": 0, "After": 12}},
+		{"skipped field", Std140, skipped{}, 16, map[string]int{"A": 0, "B": 4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			size, err := Size(test.layout, test.block)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if size != test.size {
+				t.Errorf("size is %d, want %d", size, test.size)
+			}
+			for path, want := range test.offsets {
+				offset, err := Offsetof(test.layout, test.block, path)
+				if err != nil {
+					t.Errorf("%s: %v", path, err)
+					continue
+				}
+				if offset != want {
+					t.Errorf("offset of %s is %d, want %d", path, offset, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	block := floatArray{A: 1, Values: [3]float32{2, 3, 4}, B: 5}
+	tests := []struct {
+		layout Layout
+		want   map[int]float32
+	}{
+		{Std140, map[int]float32{0: 1, 16: 2, 32: 3, 48: 4, 64: 5}},
+		{Std430, map[int]float32{0: 1, 4: 2, 8: 3, 12: 4, 16: 5}},
+	}
+	for _, test := range tests {
+		data, err := Encode(test.layout, &block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for offset, want := range test.want {
+			if got := math.Float32frombits(binary.LittleEndian.Uint32(data[offset:])); got != want {
+				t.Errorf("%v: float at %d is %v, want %v", test.layout, offset, got, want)
+			}
+		}
+	}
+
+	// mathgl matrices are column major like GLSL, std140 pads every column to a vec4
+	normal := mat3Block{Normal: mgl32.Mat3{1, 2, 3, 4, 5, 6, 7, 8, 9}}
+	data, err := Encode(Std140, normal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for column := 0; column < 3; column++ {
+		for row := 0; row < 3; row++ {
+			offset := column*16 + row*4
+			if got, want := math.Float32frombits(binary.LittleEndian.Uint32(data[offset:])), normal.Normal.At(row, column); got != want {
+				t.Errorf("column %d row %d is %v, want %v", column, row, got, want)
+			}
+		}
+	}
+
+	if err := EncodeInto(Std140, make([]byte, 8), block); err == nil {
+		t.Error("encoding into a buffer that is too small succeeded")
+	}
+
+	// A nil pointer still has a size, but nothing to encode
+	var missing *floatArray
+	if _, err := Size(Std140, missing); err != nil {
+		t.Errorf("size of a nil pointer failed: %v", err)
+	}
+	if _, err := Encode(Std140, missing); err == nil || !strings.Contains(err.Error(), "nil *layout.floatArray") {
+		t.Errorf("encoding a nil pointer returned %v", err)
+	}
+	if err := EncodeInto(Std140, make([]byte, 80), &missing); err == nil {
+		t.Error("encoding into a buffer from a nil pointer succeeded")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		block interface{}
+		want  string
+	}{
+		{"unsupported type", struct{ F float64 }{}, "unsupported type float64"},
+		{"untagged unexported field", struct {
+			A      float32
+			hidden int32
+		}{}, `must be tagged glsl:"-"`},
+		{"unsupported vector", struct {
+			V [3]float64 `glsl:",vector"`
+		}{}, "unsupported vector component type float64"},
+		{"vector too long", struct {
+			V [5]float32 `glsl:",vector"`
+		}{}, "vectors need 2 to 4 components"},
+		{"not a struct", mgl32.Vec3{}, "blocks must be structs"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Size(Std140, test.block)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("got error %v, want one containing %q", err, test.want)
+			}
+		})
+	}
+
+	if _, err := Offsetof(Std140, nestedScalar{}, "Inner.Y"); err == nil {
+		t.Error("offset of a missing member succeeded")
+	}
+	if _, err := Offsetof(Std140, floatArray{}, "Values[3]"); err == nil {
+		t.Error("offset past the end of an array succeeded")
+	}
+}
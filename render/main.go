@@ -0,0 +1,88 @@
+// Renders one frame of a registered demo offscreen and writes it to a PNG. Frames larger than the
+// GL context can render at once are rendered in tiles and stitched together, so posters can go
+// far past the largest viewport. Run it from the repository root so the demos find their assets:
+//
+//	go run ./render -size 16000x9000 -o poster.png cubes_rotating
+//	go run ./render -size 1024x768 -tile 256 -o tiled.png cube
+//
+// Scenes that update over time are advanced by -frames updates of -time-step seconds first.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/thegrandpackard/gogl/app"
+	"github.com/thegrandpackard/gogl/headless"
+	"github.com/thegrandpackard/gogl/helpers"
+	// Registers the demos
+	_ "github.com/thegrandpackard/gogl/scenes"
+)
+
+// size - A WIDTHxHEIGHT flag
+type size struct {
+	width, height int
+}
+
+func (s *size) String() string {
+	return fmt.Sprintf("%dx%d", s.width, s.height)
+}
+
+func (s *size) Set(value string) error {
+	var width, height int
+	if _, err := fmt.Sscanf(value, "%dx%d", &width, &height); err != nil || width <= 0 || height <= 0 {
+		return fmt.Errorf("invalid size %q, expected WIDTHxHEIGHT", value)
+	}
+	s.width, s.height = width, height
+	return nil
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "usage: render [flags] DEMO\n\nflags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	frame := size{1024, 768}
+	flag.Var(&frame, "size", "`WIDTHxHEIGHT` of the image in pixels")
+	out := flag.String("o", "render.png", "PNG `file` to write")
+	assetRoot := flag.String("assets", ".", "directory holding the asset folder of every demo")
+	tile := flag.Int("tile", 0, "render in tiles of at most this many pixels, 0 to tile only what the context cannot render at once")
+	samples := flag.Int("samples", 4, "MSAA samples")
+	frames := flag.Int("frames", 1, "updates run before the frame that is written")
+	timeStep := flag.Float64("time-step", 1.0/60, "seconds simulated by every update")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		usage()
+		os.Exit(2)
+	}
+	demo, ok := app.LookupDemo(flag.Arg(0))
+	if !ok {
+		log.Fatalf("unknown demo %q", flag.Arg(0))
+	}
+
+	config := app.DefaultConfig(demo.Title)
+	config.Width, config.Height = frame.width, frame.height
+	scene := demo.New(config, filepath.Join(*assetRoot, demo.Assets))
+
+	runner := headless.NewRunner()
+	runner.Width, runner.Height = frame.width, frame.height
+	runner.TileSize = *tile
+	runner.Samples = *samples
+	runner.Frames = *frames
+	runner.TimeStep = *timeStep
+
+	img, err := runner.Run(scene)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if err := helpers.SavePNG(*out, img); err != nil {
+		log.Fatalln(err)
+	}
+	log.Printf("wrote %dx%d %s to %s", frame.width, frame.height, demo.Name, *out)
+}
@@ -0,0 +1,165 @@
+package scenes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/thegrandpackard/gogl/controls"
+)
+
+// bookmarkSlots - Bookmark1 to Bookmark9 go to a bookmarked view, SetBookmark1 to SetBookmark9
+// bookmark the current one
+const bookmarkSlots = 9
+
+func bookmarkAction(slot int) string {
+	return fmt.Sprintf("Bookmark%d", slot)
+}
+
+func setBookmarkAction(slot int) string {
+	return fmt.Sprintf("SetBookmark%d", slot)
+}
+
+func init() {
+	for slot := 1; slot <= bookmarkSlots; slot++ {
+		sceneActions[bookmarkAction(slot)] = controls.Press
+		sceneActions[setBookmarkAction(slot)] = controls.Press
+	}
+}
+
+// bookmarkBindings - The number keys go to a bookmark, Ctrl and a number key sets it
+func bookmarkBindings(bindings controls.Bindings) controls.Bindings {
+	for slot := 1; slot <= bookmarkSlots; slot++ {
+		bindings[bookmarkAction(slot)] = []string{strconv.Itoa(slot)}
+		bindings[setBookmarkAction(slot)] = []string{"Ctrl+" + strconv.Itoa(slot)}
+	}
+	return bindings
+}
+
+// cameraView - Where a camera was, kept between runs and in bookmarks
+type cameraView struct {
+	Controller  string     `json:"controller"`
+	Position    [3]float32 `json:"position"`
+	Direction   [3]float32 `json:"direction"`
+	FieldOfView float32    `json:"fieldOfView"`
+
+	// Distance - From the orbit camera to the point it orbits
+	Distance float64 `json:"distance,omitempty"`
+}
+
+func (v cameraView) check() error {
+	if mgl32.Vec3(v.Direction).Len() == 0 {
+		return fmt.Errorf("direction is zero")
+	}
+	if v.FieldOfView <= 0 || v.FieldOfView >= 180 {
+		return fmt.Errorf("fieldOfView must be between 0 and 180 degrees, got %v", v.FieldOfView)
+	}
+	if v.Controller != fpsController && v.Controller != orbitController {
+		return fmt.Errorf("controller must be %q or %q, got %q", fpsController, orbitController, v.Controller)
+	}
+	return nil
+}
+
+// rigState - What a scene with a camera rig keeps between runs, by bookmark slot
+type rigState struct {
+	Camera    *cameraView           `json:"camera,omitempty"`
+	Bookmarks map[string]cameraView `json:"bookmarks,omitempty"`
+}
+
+// parseRigState - Reads and checks what a scene kept
+func parseRigState(data json.RawMessage) (*rigState, error) {
+	state := &rigState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Camera != nil {
+		if err := state.Camera.check(); err != nil {
+			return nil, fmt.Errorf("camera: %v", err)
+		}
+	}
+	for slot, view := range state.Bookmarks {
+		if n, err := strconv.Atoi(slot); err != nil || n < 1 || n > bookmarkSlots {
+			return nil, fmt.Errorf("bookmarks: slot %q is not a number from 1 to %d", slot, bookmarkSlots)
+		}
+		if err := view.check(); err != nil {
+			return nil, fmt.Errorf("bookmarks.%s: %v", slot, err)
+		}
+	}
+	return state, nil
+}
+
+// state - The current view and the bookmarks
+func (r *cameraRig) state() *rigState {
+	view := r.currentView()
+	return &rigState{Camera: &view, Bookmarks: r.bookmarks}
+}
+
+// restore - Goes back to the kept view and bookmarks
+func (r *cameraRig) restore(state *rigState, input *windowInput) {
+	if state == nil {
+		return
+	}
+	if state.Bookmarks != nil {
+		r.bookmarks = state.Bookmarks
+	}
+	if state.Camera != nil {
+		r.setView(*state.Camera, input)
+	}
+}
+
+// bookmark - Handles the bookmark actions
+func (r *cameraRig) bookmark(input *windowInput) {
+	for slot := 1; slot <= bookmarkSlots; slot++ {
+		key := strconv.Itoa(slot)
+		if input.actions.Active(setBookmarkAction(slot)) {
+			if r.bookmarks == nil {
+				r.bookmarks = make(map[string]cameraView)
+			}
+			r.bookmarks[key] = r.currentView()
+			log.Printf("bookmark %d set", slot)
+		}
+		if input.actions.Active(bookmarkAction(slot)) {
+			view, ok := r.bookmarks[key]
+			if !ok {
+				log.Printf("bookmark %d is empty, %s sets it", slot, setBookmarkAction(slot))
+				continue
+			}
+			r.setView(view, input)
+			log.Printf("bookmark %d", slot)
+		}
+	}
+}
+
+// currentView - Where the camera is, flying or not
+func (r *cameraRig) currentView() cameraView {
+	position, direction := r.active().Pose()
+	view := cameraView{Controller: fpsController, Position: position, Direction: direction, FieldOfView: r.fps.FieldOfView}
+	if r.orbiting {
+		view.Controller, view.FieldOfView, view.Distance = orbitController, r.orbit.FieldOfView, r.orbit.Distance
+	}
+	if r.flight != nil {
+		view.FieldOfView = r.flight.FieldOfView
+	}
+	return view
+}
+
+// setView - Moves the camera to view, switching controllers if it was kept with the other one
+func (r *cameraRig) setView(view cameraView, input *windowInput) {
+	r.land()
+	if (view.Controller == orbitController) != r.orbiting {
+		r.toggle(input)
+	}
+	position, direction := mgl32.Vec3(view.Position), mgl32.Vec3(view.Direction)
+	if r.orbiting {
+		r.orbit.FieldOfView = view.FieldOfView
+		if view.Distance > 0 {
+			r.orbit.Distance = view.Distance
+		}
+		r.orbit.SetPose(position, direction)
+	} else {
+		r.fps.FieldOfView = view.FieldOfView
+		r.fps.SetPose(position, direction)
+	}
+}
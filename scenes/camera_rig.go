@@ -0,0 +1,188 @@
+package scenes
+
+import (
+	"log"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/thegrandpackard/gogl/camera"
+	"github.com/thegrandpackard/gogl/controls"
+)
+
+// cameraRig - The first person camera of a scene and an orbit camera for inspecting it.
+// ToggleCamera switches between them and FrameModel frames the scene's bounds with the orbit
+// camera.
+//
+// AddKeyframe adds the current view to a camera path, which PlayPath flies along and stops again,
+// ClearPath empties and SavePath and LoadPath write to and read from the path setting's file. At
+// the end of the path the camera stays where the flight left it.
+//
+// The number keys go to the views bookmarked with Ctrl and the number.
+type cameraRig struct {
+	fps      camera.Camera
+	orbit    *camera.Orbit
+	orbiting bool
+
+	// The bounds F frames
+	min, max mgl32.Vec3
+
+	path     *camera.Path
+	pathFile string
+	loop     bool
+
+	// flight is set while flying along the path
+	flight *camera.Flythrough
+
+	// bookmarks by slot number
+	bookmarks map[string]cameraView
+}
+
+func newCameraRig(settings *cameraSettings, min, max mgl32.Vec3) *cameraRig {
+	r := &cameraRig{
+		fps:      settings.newCamera(),
+		min:      min,
+		max:      max,
+		path:     settings.newPath(),
+		pathFile: settings.Path,
+		loop:     settings.PathLoop,
+	}
+
+	r.orbit = camera.NewOrbit(min.Add(max).Mul(0.5), 10)
+	r.orbit.FieldOfView = settings.InitialFoV
+	r.orbit.DollySpeed = 0.05 * settings.ScrollSpeed
+	r.orbit.TurnSpeed = settings.TurnSpeed * math.Pi / 180
+	r.orbit.Damping = settings.Damping
+
+	if settings.Controller == orbitController {
+		r.orbiting = true
+		r.orbit.SetPose(r.fps.Pose())
+		r.orbit.Frame(min, max)
+		r.orbit.Settle()
+	}
+	return r
+}
+
+func (r *cameraRig) active() camera.Controller {
+	if r.flight != nil {
+		return r.flight
+	}
+	if r.orbiting {
+		return r.orbit
+	}
+	return &r.fps
+}
+
+func (r *cameraRig) update(input *windowInput, dt float64) {
+	r.editPath(input.actions)
+	r.bookmark(input)
+	if input.actions.Active(toggleCamera) {
+		r.land()
+		r.toggle(input)
+	}
+	if input.actions.Active(frameModel) {
+		r.land()
+		if !r.orbiting {
+			r.toggle(input)
+		}
+		r.orbit.Frame(r.min, r.max)
+	}
+
+	r.active().Update(input.snapshot(), dt)
+	if r.flight != nil && r.flight.Done() {
+		log.Printf("camera path finished")
+		r.land()
+	}
+}
+
+// editPath - Handles the path actions
+func (r *cameraRig) editPath(actions *controls.Map) {
+	if actions.Active(addKeyframe) && r.flight == nil {
+		position, direction := r.active().Pose()
+		r.path.Add(camera.NewKeyframe(position, direction))
+		log.Printf("camera path keyframe %d at %v", len(r.path.Keyframes), position)
+	}
+	if actions.Active(clearPath) {
+		r.land()
+		r.path.Clear()
+		log.Printf("camera path cleared")
+	}
+	if actions.Active(savePath) {
+		if err := r.path.Save(r.pathFile); err != nil {
+			log.Printf("failed to save the camera path: %v", err)
+		} else {
+			log.Printf("saved %d keyframe(s) to %s", len(r.path.Keyframes), r.pathFile)
+		}
+	}
+	if actions.Active(loadPath) {
+		if path, err := camera.LoadPath(r.pathFile); err != nil {
+			log.Printf("failed to load the camera path: %v", err)
+		} else {
+			r.land()
+			r.path = path
+			log.Printf("loaded %d keyframe(s) from %s", len(r.path.Keyframes), r.pathFile)
+		}
+	}
+	if actions.Active(playPath) {
+		r.fly()
+	}
+}
+
+// fly - Starts flying along the path, or stops when already flying
+func (r *cameraRig) fly() {
+	if r.flight != nil {
+		log.Printf("camera path stopped")
+		r.land()
+		return
+	}
+	if len(r.path.Keyframes) < 2 {
+		log.Printf("a camera path needs at least two keyframes, AddKeyframe adds the current view")
+		return
+	}
+
+	r.flight = camera.NewFlythrough(r.path)
+	r.flight.Loop = r.loop
+	r.flight.FieldOfView, r.flight.Near, r.flight.Far = r.fps.FieldOfView, r.fps.Near, r.fps.Far
+	if r.orbiting {
+		r.flight.FieldOfView = r.orbit.FieldOfView
+	}
+	log.Printf("flying along %d keyframe(s) for %.1f seconds", len(r.path.Keyframes), r.path.Duration())
+}
+
+// land - Stops flying, leaving the camera where the flight was
+func (r *cameraRig) land() {
+	if r.flight == nil {
+		return
+	}
+	position, direction := r.flight.Pose()
+	r.flight = nil
+	r.active().SetPose(position, direction)
+}
+
+// toggle - Switches controllers, keeping the view where it is
+func (r *cameraRig) toggle(input *windowInput) {
+	position, direction := r.active().Pose()
+	if r.orbiting {
+		r.fps.FieldOfView = r.orbit.FieldOfView
+		r.fps.SetPose(position, direction)
+		log.Printf("first person camera")
+	} else {
+		// Orbit around the middle of the scene's depth
+		r.orbit.Distance = float64(r.min.Add(r.max).Mul(0.5).Sub(position).Dot(direction))
+		if r.orbit.Distance < r.orbit.MinDistance {
+			r.orbit.Distance = r.orbit.MinDistance
+		}
+		r.orbit.FieldOfView = r.fps.FieldOfView
+		r.orbit.SetPose(position, direction)
+		log.Printf("orbit camera, drag to rotate or pan, FrameModel to frame")
+	}
+	r.orbiting = !r.orbiting
+	input.setFree(r.orbiting)
+}
+
+func (r *cameraRig) view(alpha float64) mgl32.Mat4 {
+	return r.active().InterpolatedView(alpha)
+}
+
+func (r *cameraRig) projection(aspect float32) mgl32.Mat4 {
+	return r.active().Projection(aspect)
+}
@@ -0,0 +1,170 @@
+package scenes
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/thegrandpackard/gogl/app"
+	"github.com/thegrandpackard/gogl/gl"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// Cube - A cube with one face of die.png on every side
+type Cube struct {
+	size
+	assets
+	sceneSettings
+	shaders
+
+	vao, vbo uint32
+	program  uint32
+	texture  uint32
+
+	matrices     *helpers.MatrixUniforms
+	modelUniform int32
+}
+
+// NewCube - Returns the cube scene for a width x height target, loading die.png from assetDir
+func NewCube(width, height int, assetDir string) *Cube {
+	return &Cube{sceneSettings: defaultSceneSettings, size: size{Width: width, Height: height}, assets: assets{AssetDir: assetDir}}
+}
+
+func init() {
+	app.Register(app.Demo{
+		Name:        "cube",
+		Title:       "Textured Cube",
+		Description: "A cube textured with die.png",
+		Assets:      "cube",
+		New: func(config app.Config, assetDir string) app.App {
+			return NewCube(config.Width, config.Height, assetDir)
+		},
+	})
+}
+
+// Init - Creates the GL objects of the scene
+func (s *Cube) Init() error {
+	s.clear()
+
+	// Enable depth test
+	gl.Enable(gl.DEPTH_TEST)
+	// Accept fragment if it closer to the camera than the former one
+	gl.DepthFunc(gl.LESS)
+
+	// Configure the vertex data
+	s.vao = helpers.GenVertexArray()
+	gl.BindVertexArray(s.vao)
+
+	// Configure the vertex and fragment shaders
+	program, err := s.variant(helpers.Textured)
+	if err != nil {
+		return err
+	}
+	s.program = program
+
+	matrices, err := helpers.NewMatrixUniforms(program)
+	if err != nil {
+		return err
+	}
+	s.matrices = matrices
+	s.modelUniform = gl.GetUniformLocation(program, gl.Str("model\x00"))
+
+	s.vbo = helpers.GenBuffer()
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(cubeVertices)*4, gl.Ptr(cubeVertices), gl.STATIC_DRAW)
+
+	vertAttrib := uint32(gl.GetAttribLocation(program, gl.Str("vert\x00")))
+	gl.EnableVertexAttribArray(vertAttrib)
+	gl.VertexAttribPointer(vertAttrib, 3, gl.FLOAT, false, 5*4, gl.PtrOffset(0))
+
+	texCoordAttrib := uint32(gl.GetAttribLocation(program, gl.Str("vertTexCoord\x00")))
+	gl.EnableVertexAttribArray(texCoordAttrib)
+	gl.VertexAttribPointer(texCoordAttrib, 2, gl.FLOAT, false, 5*4, gl.PtrOffset(3*4))
+
+	texture, err := s.loadTexture("die.png")
+	if err != nil {
+		return err
+	}
+	s.texture = texture
+
+	return nil
+}
+
+// Update - The cube does not move
+func (s *Cube) Update(dt float64) {}
+
+// Render - Draws one frame
+func (s *Cube) Render(alpha float64) {
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+
+	gl.UseProgram(s.program)
+	gl.BindVertexArray(s.vao)
+
+	projection := s.project(mgl32.Perspective(mgl32.DegToRad(45.0), s.aspect(), 0.1, 10))
+	model := mgl32.Ident4()
+	s.matrices.Set(projection, staticCamera)
+	gl.UniformMatrix4fv(s.modelUniform, 1, false, &model[0])
+
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, s.texture)
+
+	gl.DrawArrays(gl.TRIANGLES, 0, 6*2*3)
+}
+
+// Shutdown - Releases the GL objects of the scene
+func (s *Cube) Shutdown() {
+	helpers.DeleteBuffer(s.vbo)
+	helpers.DeleteVertexArray(s.vao)
+	s.matrices.Delete()
+	s.releaseShaders()
+	s.releaseTexture(s.texture)
+	gl.Disable(gl.DEPTH_TEST)
+}
+
+var cubeVertices = []float32{
+	//  X, Y, Z, U, V
+	// Bottom, the 5
+	-1.0, -1.0, -1.0, 0.333333, 0.666667,
+	1.0, -1.0, -1.0, 0.666667, 0.666667,
+	-1.0, -1.0, 1.0, 0.333333, 0.333333,
+	1.0, -1.0, -1.0, 0.666667, 0.666667,
+	1.0, -1.0, 1.0, 0.666667, 0.333333,
+	-1.0, -1.0, 1.0, 0.333333, 0.333333,
+
+	// Top, the 2
+	-1.0, 1.0, -1.0, 0.333333, 0.000000,
+	-1.0, 1.0, 1.0, 0.333333, 0.333333,
+	1.0, 1.0, -1.0, 0.666667, 0.000000,
+	1.0, 1.0, -1.0, 0.666667, 0.000000,
+	-1.0, 1.0, 1.0, 0.333333, 0.333333,
+	1.0, 1.0, 1.0, 0.666667, 0.333333,
+
+	// Front, the 1
+	-1.0, -1.0, 1.0, 0.000000, 0.333333,
+	1.0, -1.0, 1.0, 0.333333, 0.333333,
+	-1.0, 1.0, 1.0, 0.000000, 0.000000,
+	1.0, -1.0, 1.0, 0.333333, 0.333333,
+	1.0, 1.0, 1.0, 0.333333, 0.000000,
+	-1.0, 1.0, 1.0, 0.000000, 0.000000,
+
+	// Back, the 6
+	-1.0, -1.0, -1.0, 1.000000, 0.666667,
+	-1.0, 1.0, -1.0, 1.000000, 0.333333,
+	1.0, -1.0, -1.0, 0.666667, 0.666667,
+	1.0, -1.0, -1.0, 0.666667, 0.666667,
+	-1.0, 1.0, -1.0, 1.000000, 0.333333,
+	1.0, 1.0, -1.0, 0.666667, 0.333333,
+
+	// Left, the 4
+	-1.0, -1.0, 1.0, 0.333333, 0.666667,
+	-1.0, 1.0, -1.0, 0.000000, 0.333333,
+	-1.0, -1.0, -1.0, 0.000000, 0.666667,
+	-1.0, -1.0, 1.0, 0.333333, 0.666667,
+	-1.0, 1.0, 1.0, 0.333333, 0.333333,
+	-1.0, 1.0, -1.0, 0.000000, 0.333333,
+
+	// Right, the 3
+	1.0, -1.0, 1.0, 0.666667, 0.333333,
+	1.0, -1.0, -1.0, 1.000000, 0.333333,
+	1.0, 1.0, -1.0, 1.000000, 0.000000,
+	1.0, -1.0, 1.0, 0.666667, 0.333333,
+	1.0, 1.0, -1.0, 1.000000, 0.000000,
+	1.0, 1.0, 1.0, 0.666667, 0.000000,
+}
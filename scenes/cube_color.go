@@ -0,0 +1,204 @@
+package scenes
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/thegrandpackard/gogl/app"
+	"github.com/thegrandpackard/gogl/gl"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// CubeColor - A cube with a color per vertex
+type CubeColor struct {
+	size
+	sceneSettings
+	shaders
+
+	vao, vbo, cbo uint32
+	program       uint32
+
+	matrices     *helpers.MatrixUniforms
+	modelUniform int32
+}
+
+// NewCubeColor - Returns the colored cube scene for a width x height target
+func NewCubeColor(width, height int) *CubeColor {
+	return &CubeColor{sceneSettings: defaultSceneSettings, size: size{Width: width, Height: height}}
+}
+
+func init() {
+	app.Register(app.Demo{
+		Name:        "cube_color",
+		Title:       "Colored Cube",
+		Description: "A cube with a color per vertex",
+		New: func(config app.Config, assetDir string) app.App {
+			return NewCubeColor(config.Width, config.Height)
+		},
+	})
+}
+
+// Init - Creates the GL objects of the scene
+func (s *CubeColor) Init() error {
+	s.clear()
+
+	// Enable depth test
+	gl.Enable(gl.DEPTH_TEST)
+	// Accept fragment if it closer to the camera than the former one
+	gl.DepthFunc(gl.LESS)
+
+	// Configure the vertex data
+	s.vao = helpers.GenVertexArray()
+	gl.BindVertexArray(s.vao)
+
+	// Configure the vertex and fragment shaders
+	program, err := s.variant(helpers.VertexColor)
+	if err != nil {
+		return err
+	}
+	s.program = program
+
+	matrices, err := helpers.NewMatrixUniforms(program)
+	if err != nil {
+		return err
+	}
+	s.matrices = matrices
+	s.modelUniform = gl.GetUniformLocation(program, gl.Str("model\x00"))
+
+	s.vbo = helpers.GenBuffer()
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(cubeColorVertices)*4, gl.Ptr(cubeColorVertices), gl.STATIC_DRAW)
+
+	s.cbo = helpers.GenBuffer()
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.cbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(cubeColorColors)*4, gl.Ptr(cubeColorColors), gl.STATIC_DRAW)
+
+	return nil
+}
+
+// Update - The cube does not move
+func (s *CubeColor) Update(dt float64) {}
+
+// Render - Draws one frame
+func (s *CubeColor) Render(alpha float64) {
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+
+	gl.UseProgram(s.program)
+	gl.BindVertexArray(s.vao)
+
+	projection := s.project(mgl32.Perspective(mgl32.DegToRad(45.0), s.aspect(), 0.1, 10))
+	model := mgl32.Ident4()
+	s.matrices.Set(projection, staticCamera)
+	gl.UniformMatrix4fv(s.modelUniform, 1, false, &model[0])
+
+	gl.EnableVertexAttribArray(0)
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.vbo)
+	gl.VertexAttribPointer(0, 3, gl.FLOAT, false, 0, gl.PtrOffset(0))
+
+	gl.EnableVertexAttribArray(1)
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.cbo)
+	gl.VertexAttribPointer(1, 3, gl.FLOAT, false, 0, gl.PtrOffset(0))
+
+	gl.DrawArrays(gl.TRIANGLES, 0, 6*2*3)
+
+	gl.DisableVertexAttribArray(0)
+	gl.DisableVertexAttribArray(1)
+}
+
+// Shutdown - Releases the GL objects of the scene
+func (s *CubeColor) Shutdown() {
+	helpers.DeleteBuffer(s.vbo)
+	helpers.DeleteBuffer(s.cbo)
+	helpers.DeleteVertexArray(s.vao)
+	s.matrices.Delete()
+	s.releaseShaders()
+	gl.Disable(gl.DEPTH_TEST)
+}
+
+var cubeColorVertices = []float32{
+	//  X, Y, Z
+	// Bottom
+	-1.0, -1.0, -1.0,
+	1.0, -1.0, -1.0,
+	-1.0, -1.0, 1.0,
+	1.0, -1.0, -1.0,
+	1.0, -1.0, 1.0,
+	-1.0, -1.0, 1.0,
+
+	// Top
+	-1.0, 1.0, -1.0,
+	-1.0, 1.0, 1.0,
+	1.0, 1.0, -1.0,
+	1.0, 1.0, -1.0,
+	-1.0, 1.0, 1.0,
+	1.0, 1.0, 1.0,
+
+	// Front
+	-1.0, -1.0, 1.0,
+	1.0, -1.0, 1.0,
+	-1.0, 1.0, 1.0,
+	1.0, -1.0, 1.0,
+	1.0, 1.0, 1.0,
+	-1.0, 1.0, 1.0,
+
+	// Back
+	-1.0, -1.0, -1.0,
+	-1.0, 1.0, -1.0,
+	1.0, -1.0, -1.0,
+	1.0, -1.0, -1.0,
+	-1.0, 1.0, -1.0,
+	1.0, 1.0, -1.0,
+
+	// Left
+	-1.0, -1.0, 1.0,
+	-1.0, 1.0, -1.0,
+	-1.0, -1.0, -1.0,
+	-1.0, -1.0, 1.0,
+	-1.0, 1.0, 1.0,
+	-1.0, 1.0, -1.0,
+
+	// Right
+	1.0, -1.0, 1.0,
+	1.0, -1.0, -1.0,
+	1.0, 1.0, -1.0,
+	1.0, -1.0, 1.0,
+	1.0, 1.0, -1.0,
+	1.0, 1.0, 1.0,
+}
+
+var cubeColorColors = []float32{
+	0.583, 0.771, 0.014,
+	0.609, 0.115, 0.436,
+	0.327, 0.483, 0.844,
+	0.822, 0.569, 0.201,
+	0.435, 0.602, 0.223,
+	0.310, 0.747, 0.185,
+	0.597, 0.770, 0.761,
+	0.559, 0.436, 0.730,
+	0.359, 0.583, 0.152,
+	0.483, 0.596, 0.789,
+	0.559, 0.861, 0.639,
+	0.195, 0.548, 0.859,
+	0.014, 0.184, 0.576,
+	0.771, 0.328, 0.970,
+	0.406, 0.615, 0.116,
+	0.676, 0.977, 0.133,
+	0.971, 0.572, 0.833,
+	0.140, 0.616, 0.489,
+	0.997, 0.513, 0.064,
+	0.945, 0.719, 0.592,
+	0.543, 0.021, 0.978,
+	0.279, 0.317, 0.505,
+	0.167, 0.620, 0.077,
+	0.347, 0.857, 0.137,
+	0.055, 0.953, 0.042,
+	0.714, 0.505, 0.345,
+	0.783, 0.290, 0.734,
+	0.722, 0.645, 0.174,
+	0.302, 0.455, 0.848,
+	0.225, 0.587, 0.040,
+	0.517, 0.713, 0.338,
+	0.053, 0.959, 0.120,
+	0.393, 0.621, 0.362,
+	0.673, 0.211, 0.457,
+	0.820, 0.883, 0.371,
+	0.982, 0.099, 0.879,
+}
@@ -0,0 +1,201 @@
+package scenes
+
+import (
+	"encoding/json"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/thegrandpackard/gogl/app"
+	"github.com/thegrandpackard/gogl/controls"
+	"github.com/thegrandpackard/gogl/gl"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// CubeKeyboardMouse - The die with a camera steered by the arrow keys, the mouse and the scroll wheel
+type CubeKeyboardMouse struct {
+	size
+	assets
+	cameraSettings
+	shaders
+
+	// input comes from the device set with SetInput, or else the window set with SetWindow. Without
+	// either the camera stays at its starting pose.
+	input     windowInput
+	camera    *cameraRig
+	wireframe bool
+
+	// kept is what RestoreState handed over, applied once the camera exists
+	kept *rigState
+
+	vao, vbo uint32
+	program  uint32
+	texture  uint32
+
+	matrices     *helpers.MatrixUniforms
+	modelUniform int32
+
+	model mgl32.Mat4
+}
+
+// NewCubeKeyboardMouse - Returns the scene for a width x height target, loading d6.png from assetDir
+func NewCubeKeyboardMouse(width, height int, assetDir string) *CubeKeyboardMouse {
+	return &CubeKeyboardMouse{
+		size:   size{Width: width, Height: height},
+		assets: assets{AssetDir: assetDir},
+		cameraSettings: cameraSettings{
+			sceneSettings:   defaultSceneSettings,
+			Position:        [3]float32{0, 0, 5},
+			HorizontalAngle: 3.14,
+			InitialFoV:      45.0,
+			Speed:           3.0,
+			Sensitivity:     0.3,
+			ScrollSpeed:     2.0,
+			Controller:      fpsController,
+			Damping:         0.1,
+			TurnSpeed:       120,
+			Deadzone:        0.15,
+			ResponseCurve:   2,
+			Path:            "camera_path.json",
+			PathSpline:      "catmull-rom",
+			PathSpeed:       2,
+			Bindings:        arrowBindings(),
+		},
+		model: mgl32.Ident4(),
+	}
+}
+
+func init() {
+	app.Register(app.Demo{
+		Name:        "cube_keyboard_mouse",
+		Title:       "Keyboard and Mouse Camera",
+		Description: "The die with a camera steered by the arrow keys, the mouse and the scroll wheel",
+		Assets:      "cube_keyboard_mouse",
+		New: func(config app.Config, assetDir string) app.App {
+			return NewCubeKeyboardMouse(config.Width, config.Height, assetDir)
+		},
+	})
+}
+
+// Init - Creates the GL objects of the scene and hooks up the window's input
+func (s *CubeKeyboardMouse) Init() error {
+	s.clear()
+
+	// Start from the configured pose
+	s.camera = newCameraRig(&s.cameraSettings, mgl32.Vec3{-1, -1, -1}, mgl32.Vec3{1, 1, 1})
+
+	// Enable depth test
+	gl.Enable(gl.DEPTH_TEST)
+	// Accept fragment if it closer to the camera than the former one
+	gl.DepthFunc(gl.LESS)
+	// Cull triangles
+	gl.Enable(gl.CULL_FACE)
+
+	// Configure the vertex data
+	s.vao = helpers.GenVertexArray()
+	gl.BindVertexArray(s.vao)
+
+	// Configure the vertex and fragment shaders
+	program, err := s.variant(helpers.Textured)
+	if err != nil {
+		return err
+	}
+	s.program = program
+
+	matrices, err := helpers.NewMatrixUniforms(program)
+	if err != nil {
+		return err
+	}
+	s.matrices = matrices
+	s.modelUniform = gl.GetUniformLocation(program, gl.Str("model\x00"))
+
+	s.vbo = helpers.GenBuffer()
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(d6Vertices)*4, gl.Ptr(d6Vertices), gl.STATIC_DRAW)
+
+	vertAttrib := uint32(gl.GetAttribLocation(program, gl.Str("vert\x00")))
+	texCoordAttrib := uint32(gl.GetAttribLocation(program, gl.Str("vertTexCoord\x00")))
+
+	gl.EnableVertexAttribArray(vertAttrib)
+	gl.VertexAttribPointer(vertAttrib, 3, gl.FLOAT, false, 5*4, gl.PtrOffset(0))
+
+	gl.EnableVertexAttribArray(texCoordAttrib)
+	gl.VertexAttribPointer(texCoordAttrib, 2, gl.FLOAT, false, 5*4, gl.PtrOffset(3*4))
+
+	texture, err := s.loadTexture("d6.png")
+	if err != nil {
+		return err
+	}
+	s.texture = texture
+
+	s.input.free = s.camera.orbiting
+	if err := s.input.attach(&s.cameraSettings); err != nil {
+		return err
+	}
+	s.camera.restore(s.kept, &s.input)
+	return nil
+}
+
+// SaveState - Keeps the camera's view and the bookmarks
+func (s *CubeKeyboardMouse) SaveState() (json.RawMessage, error) {
+	return json.Marshal(s.camera.state())
+}
+
+// RestoreState - Starts the camera where it was kept, with the kept bookmarks
+func (s *CubeKeyboardMouse) RestoreState(state json.RawMessage) error {
+	kept, err := parseRigState(state)
+	if err != nil {
+		return err
+	}
+	s.kept = kept
+	return nil
+}
+
+// SetWindow - Makes the scene read its input from window
+func (s *CubeKeyboardMouse) SetWindow(window controls.Window) {
+	s.input.window = window
+}
+
+// SetInput - Makes the scene read its input from device, which the caller polls before every update
+func (s *CubeKeyboardMouse) SetInput(device *controls.Device) {
+	s.input.device = device
+}
+
+// Update - Moves the camera according to the input of the last dt seconds
+func (s *CubeKeyboardMouse) Update(dt float64) {
+	s.input.poll()
+	s.camera.update(&s.input, dt)
+	if s.input.actions.Active(toggleWireframe) {
+		s.wireframe = !s.wireframe
+	}
+}
+
+// Render - Draws one frame
+func (s *CubeKeyboardMouse) Render(alpha float64) {
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+	if s.wireframe {
+		gl.PolygonMode(gl.FRONT_AND_BACK, gl.LINE)
+		defer gl.PolygonMode(gl.FRONT_AND_BACK, gl.FILL)
+	}
+
+	gl.UseProgram(s.program)
+	gl.BindVertexArray(s.vao)
+	// The aspect can change between updates when the window is resized
+	projection := s.project(s.camera.projection(s.aspect()))
+	s.matrices.Set(projection, s.camera.view(alpha))
+	gl.UniformMatrix4fv(s.modelUniform, 1, false, &s.model[0])
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, s.texture)
+	gl.DrawArrays(gl.TRIANGLES, 0, 6*2*3)
+}
+
+// Shutdown - Releases the GL objects of the scene and unhooks the window's input
+func (s *CubeKeyboardMouse) Shutdown() {
+	s.input.detach()
+
+	helpers.DeleteBuffer(s.vbo)
+	helpers.DeleteVertexArray(s.vao)
+	s.matrices.Delete()
+	s.releaseShaders()
+	s.releaseTexture(s.texture)
+	gl.Disable(gl.CULL_FACE)
+	gl.Disable(gl.DEPTH_TEST)
+}
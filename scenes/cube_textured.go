@@ -0,0 +1,158 @@
+package scenes
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/thegrandpackard/gogl/app"
+	"github.com/thegrandpackard/gogl/gl"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// CubeTextured - A die textured with d6.png
+type CubeTextured struct {
+	size
+	assets
+	sceneSettings
+	shaders
+
+	vao, vbo uint32
+	program  uint32
+	texture  uint32
+
+	matrices     *helpers.MatrixUniforms
+	modelUniform int32
+}
+
+// NewCubeTextured - Returns the die scene for a width x height target, loading d6.png from assetDir
+func NewCubeTextured(width, height int, assetDir string) *CubeTextured {
+	return &CubeTextured{sceneSettings: defaultSceneSettings, size: size{Width: width, Height: height}, assets: assets{AssetDir: assetDir}}
+}
+
+func init() {
+	app.Register(app.Demo{
+		Name:        "cube_textured",
+		Title:       "Textured Die",
+		Description: "A die textured with d6.png",
+		Assets:      "cube_textured",
+		New: func(config app.Config, assetDir string) app.App {
+			return NewCubeTextured(config.Width, config.Height, assetDir)
+		},
+	})
+}
+
+// Init - Creates the GL objects of the scene
+func (s *CubeTextured) Init() error {
+	s.clear()
+
+	// Enable depth test
+	gl.Enable(gl.DEPTH_TEST)
+	// Accept fragment if it closer to the camera than the former one
+	gl.DepthFunc(gl.LESS)
+
+	// Configure the vertex data
+	s.vao = helpers.GenVertexArray()
+	gl.BindVertexArray(s.vao)
+
+	// Configure the vertex and fragment shaders
+	program, err := s.variant(helpers.Textured)
+	if err != nil {
+		return err
+	}
+	s.program = program
+
+	matrices, err := helpers.NewMatrixUniforms(program)
+	if err != nil {
+		return err
+	}
+	s.matrices = matrices
+	s.modelUniform = gl.GetUniformLocation(program, gl.Str("model\x00"))
+
+	s.vbo = helpers.GenBuffer()
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(d6Vertices)*4, gl.Ptr(d6Vertices), gl.STATIC_DRAW)
+
+	vertAttrib := uint32(gl.GetAttribLocation(program, gl.Str("vert\x00")))
+	gl.EnableVertexAttribArray(vertAttrib)
+	gl.VertexAttribPointer(vertAttrib, 3, gl.FLOAT, false, 5*4, gl.PtrOffset(0))
+
+	texCoordAttrib := uint32(gl.GetAttribLocation(program, gl.Str("vertTexCoord\x00")))
+	gl.EnableVertexAttribArray(texCoordAttrib)
+	gl.VertexAttribPointer(texCoordAttrib, 2, gl.FLOAT, false, 5*4, gl.PtrOffset(3*4))
+
+	texture, err := s.loadTexture("d6.png")
+	if err != nil {
+		return err
+	}
+	s.texture = texture
+
+	return nil
+}
+
+// Update - The die does not move
+func (s *CubeTextured) Update(dt float64) {}
+
+// Render - Draws one frame
+func (s *CubeTextured) Render(alpha float64) {
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+
+	gl.UseProgram(s.program)
+	gl.BindVertexArray(s.vao)
+
+	projection := s.project(mgl32.Perspective(mgl32.DegToRad(45.0), s.aspect(), 0.1, 10))
+	model := mgl32.Ident4()
+	s.matrices.Set(projection, staticCamera)
+	gl.UniformMatrix4fv(s.modelUniform, 1, false, &model[0])
+
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, s.texture)
+
+	gl.DrawArrays(gl.TRIANGLES, 0, 6*2*3)
+}
+
+// Shutdown - Releases the GL objects of the scene
+func (s *CubeTextured) Shutdown() {
+	helpers.DeleteBuffer(s.vbo)
+	helpers.DeleteVertexArray(s.vao)
+	s.matrices.Delete()
+	s.releaseShaders()
+	s.releaseTexture(s.texture)
+	gl.Disable(gl.DEPTH_TEST)
+}
+
+var d6Vertices = []float32{
+	-1.0, -1.0, -1.0, 0.000059, 0.000004,
+	-1.0, -1.0, 1.0, 0.000103, 0.336048,
+	-1.0, 1.0, 1.0, 0.335973, 0.335903,
+	1.0, 1.0, -1.0, 1.000023, 0.000013,
+	-1.0, -1.0, -1.0, 0.667979, 0.335851,
+	-1.0, 1.0, -1.0, 0.999958, 0.336064,
+	1.0, -1.0, 1.0, 0.667979, 0.335851,
+	-1.0, -1.0, -1.0, 0.336024, 0.671877,
+	1.0, -1.0, -1.0, 0.667969, 0.671889,
+	1.0, 1.0, -1.0, 1.000023, 0.000013,
+	1.0, -1.0, -1.0, 0.668104, 0.000013,
+	-1.0, -1.0, -1.0, 0.667979, 0.335851,
+	-1.0, -1.0, -1.0, 0.000059, 0.000004,
+	-1.0, 1.0, 1.0, 0.335973, 0.335903,
+	-1.0, 1.0, -1.0, 0.336098, 0.000071,
+	1.0, -1.0, 1.0, 0.667979, 0.335851,
+	-1.0, -1.0, 1.0, 0.335973, 0.335903,
+	-1.0, -1.0, -1.0, 0.336024, 0.671877,
+	-1.0, 1.0, 1.0, 1.000004, 0.671847,
+	-1.0, -1.0, 1.0, 0.999958, 0.336064,
+	1.0, -1.0, 1.0, 0.667979, 0.335851,
+	1.0, 1.0, 1.0, 0.668104, 0.000013,
+	1.0, -1.0, -1.0, 0.335973, 0.335903,
+	1.0, 1.0, -1.0, 0.667979, 0.335851,
+	1.0, -1.0, -1.0, 0.335973, 0.335903,
+	1.0, 1.0, 1.0, 0.668104, 0.000013,
+	1.0, -1.0, 1.0, 0.336098, 0.000071,
+	1.0, 1.0, 1.0, 0.000103, 0.336048,
+	1.0, 1.0, -1.0, 0.000004, 0.671870,
+	-1.0, 1.0, -1.0, 0.336024, 0.671877,
+	1.0, 1.0, 1.0, 0.000103, 0.336048,
+	-1.0, 1.0, -1.0, 0.336024, 0.671877,
+	-1.0, 1.0, 1.0, 0.335973, 0.335903,
+	1.0, 1.0, 1.0, 0.667969, 0.671889,
+	-1.0, 1.0, 1.0, 1.000004, 0.671847,
+	1.0, -1.0, 1.0, 0.667979, 0.335851,
+}
@@ -0,0 +1,131 @@
+package scenes
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/thegrandpackard/gogl/app"
+	"github.com/thegrandpackard/gogl/gl"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// CubeTriangle - A colored cube next to a triangle sharing its colors
+type CubeTriangle struct {
+	size
+	sceneSettings
+	shaders
+
+	vao, vboCube, vboTriangle, cbo uint32
+	program                        uint32
+
+	matrices     *helpers.MatrixUniforms
+	modelUniform int32
+}
+
+// NewCubeTriangle - Returns the cube and triangle scene for a width x height target
+func NewCubeTriangle(width, height int) *CubeTriangle {
+	return &CubeTriangle{sceneSettings: defaultSceneSettings, size: size{Width: width, Height: height}}
+}
+
+func init() {
+	app.Register(app.Demo{
+		Name:        "cube_triangle",
+		Title:       "Cube and Triangle",
+		Description: "A colored cube next to a triangle sharing its colors",
+		New: func(config app.Config, assetDir string) app.App {
+			return NewCubeTriangle(config.Width, config.Height)
+		},
+	})
+}
+
+// Init - Creates the GL objects of the scene
+func (s *CubeTriangle) Init() error {
+	s.clear()
+
+	// Enable depth test
+	gl.Enable(gl.DEPTH_TEST)
+	// Accept fragment if it closer to the camera than the former one
+	gl.DepthFunc(gl.LESS)
+
+	// Configure the vertex data
+	s.vao = helpers.GenVertexArray()
+	gl.BindVertexArray(s.vao)
+
+	// Configure the vertex and fragment shaders
+	program, err := s.variant(helpers.VertexColor)
+	if err != nil {
+		return err
+	}
+	s.program = program
+
+	matrices, err := helpers.NewMatrixUniforms(program)
+	if err != nil {
+		return err
+	}
+	s.matrices = matrices
+	s.modelUniform = gl.GetUniformLocation(program, gl.Str("model\x00"))
+
+	s.vboCube = helpers.GenBuffer()
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.vboCube)
+	gl.BufferData(gl.ARRAY_BUFFER, len(cubeColorVertices)*4, gl.Ptr(cubeColorVertices), gl.STATIC_DRAW)
+
+	s.cbo = helpers.GenBuffer()
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.cbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(cubeColorColors)*4, gl.Ptr(cubeColorColors), gl.STATIC_DRAW)
+
+	s.vboTriangle = helpers.GenBuffer()
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.vboTriangle)
+	gl.BufferData(gl.ARRAY_BUFFER, len(triangleVertices)*4, gl.Ptr(triangleVertices), gl.STATIC_DRAW)
+
+	return nil
+}
+
+// Update - Nothing moves
+func (s *CubeTriangle) Update(dt float64) {}
+
+// Render - Draws one frame
+func (s *CubeTriangle) Render(alpha float64) {
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+
+	gl.UseProgram(s.program)
+	gl.BindVertexArray(s.vao)
+
+	projection := s.project(mgl32.Perspective(mgl32.DegToRad(45.0), s.aspect(), 0.1, 10))
+	model := mgl32.Ident4()
+	model2 := mgl32.Translate3D(2, 0, 0)
+	s.matrices.Set(projection, staticCamera)
+	gl.UniformMatrix4fv(s.modelUniform, 1, false, &model[0])
+
+	// Draw the cube
+	gl.EnableVertexAttribArray(0)
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.vboCube)
+	gl.VertexAttribPointer(0, 3, gl.FLOAT, false, 0, gl.PtrOffset(0))
+	gl.EnableVertexAttribArray(1)
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.cbo)
+	gl.VertexAttribPointer(1, 3, gl.FLOAT, false, 0, gl.PtrOffset(0))
+	gl.DrawArrays(gl.TRIANGLES, 0, 6*2*3)
+	gl.DisableVertexAttribArray(0)
+	gl.DisableVertexAttribArray(1)
+
+	// Draw the triangle
+	gl.UniformMatrix4fv(s.modelUniform, 1, false, &model2[0])
+	gl.EnableVertexAttribArray(0)
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.vboTriangle)
+	gl.VertexAttribPointer(0, 3, gl.FLOAT, false, 0, gl.PtrOffset(0))
+	gl.EnableVertexAttribArray(1)
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.cbo)
+	gl.VertexAttribPointer(1, 3, gl.FLOAT, false, 0, gl.PtrOffset(0))
+	gl.DrawArrays(gl.TRIANGLES, 0, 3)
+
+	gl.DisableVertexAttribArray(0)
+	gl.DisableVertexAttribArray(1)
+}
+
+// Shutdown - Releases the GL objects of the scene
+func (s *CubeTriangle) Shutdown() {
+	helpers.DeleteBuffer(s.vboCube)
+	helpers.DeleteBuffer(s.vboTriangle)
+	helpers.DeleteBuffer(s.cbo)
+	helpers.DeleteVertexArray(s.vao)
+	s.matrices.Delete()
+	s.releaseShaders()
+	gl.Disable(gl.DEPTH_TEST)
+}
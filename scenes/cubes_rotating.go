@@ -0,0 +1,286 @@
+package scenes
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/thegrandpackard/gogl/app"
+	"github.com/thegrandpackard/gogl/controls"
+	"github.com/thegrandpackard/gogl/gl"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// CubesRotating - A ring of dice around one in the middle, explored with WASD and mouse look
+type CubesRotating struct {
+	size
+	assets
+	cameraSettings
+	shaders
+
+	// input comes from the device set with SetInput, or else the window set with SetWindow. Without
+	// either the camera stays at its starting pose.
+	input     windowInput
+	camera    *cameraRig
+	wireframe bool
+
+	// kept is what RestoreState handed over, applied once the camera exists
+	kept *rigState
+
+	vao, vbo uint32
+	program  uint32
+	texture  uint32
+
+	matrices     *helpers.MatrixUniforms
+	modelUniform int32
+
+	// The angles are logged whenever they change
+	lastHorizontalAngle, lastVerticalAngle float64
+
+	models []mgl32.Mat4
+}
+
+// NewCubesRotating - Returns the scene for a width x height target, loading d6.png from assetDir
+func NewCubesRotating(width, height int, assetDir string) *CubesRotating {
+	return &CubesRotating{
+		size:   size{Width: width, Height: height},
+		assets: assets{AssetDir: assetDir},
+		cameraSettings: cameraSettings{
+			sceneSettings: defaultSceneSettings,
+			Position:      [3]float32{0, 0, -15},
+			InitialFoV:    45.0,
+			Speed:         3.0,
+			Sensitivity:   0.3,
+			ScrollSpeed:   2.0,
+			Controller:    fpsController,
+			Damping:       0.1,
+			TurnSpeed:     120,
+			Deadzone:      0.15,
+			ResponseCurve: 2,
+			Path:          "camera_path.json",
+			PathSpline:    "catmull-rom",
+			PathSpeed:     2,
+			Bindings:      wasdBindings(),
+		},
+	}
+}
+
+func init() {
+	app.Register(app.Demo{
+		Name:        "cubes_rotating",
+		Title:       "Ring of Dice",
+		Description: "A ring of dice around one in the middle, explored with WASD and mouse look",
+		Assets:      "cubes_rotating",
+		New: func(config app.Config, assetDir string) app.App {
+			return NewCubesRotating(config.Width, config.Height, assetDir)
+		},
+	})
+
+	app.Register(app.Demo{
+		Name:        "model_loading",
+		Title:       "Orbiting the Dice",
+		Description: "The ring of dice inspected with an orbit camera",
+		Assets:      "cubes_rotating",
+		New: func(config app.Config, assetDir string) app.App {
+			scene := NewCubesRotating(config.Width, config.Height, assetDir)
+			scene.Controller = orbitController
+			return scene
+		},
+	})
+}
+
+// Init - Creates the GL objects of the scene and hooks up the window's input
+func (s *CubesRotating) Init() error {
+	s.clear()
+
+	// Start from the configured pose
+	s.camera = newCameraRig(&s.cameraSettings, mgl32.Vec3{-6, -1, -6}, mgl32.Vec3{6, 1, 6})
+
+	// Enable depth test
+	gl.Enable(gl.DEPTH_TEST)
+	// Accept fragment if it closer to the camera than the former one
+	gl.DepthFunc(gl.LESS)
+	// Cull triangles
+	gl.Enable(gl.CULL_FACE)
+
+	// Configure the vertex data
+	s.vao = helpers.GenVertexArray()
+	gl.BindVertexArray(s.vao)
+
+	// Configure the vertex and fragment shaders
+	program, err := s.variant(helpers.Textured)
+	if err != nil {
+		return err
+	}
+	s.program = program
+
+	matrices, err := helpers.NewMatrixUniforms(program)
+	if err != nil {
+		return err
+	}
+	s.matrices = matrices
+	s.modelUniform = gl.GetUniformLocation(program, gl.Str("model\x00"))
+
+	s.vbo = helpers.GenBuffer()
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(diceVertices)*4, gl.Ptr(diceVertices), gl.STATIC_DRAW)
+
+	vertAttrib := uint32(gl.GetAttribLocation(program, gl.Str("vert\x00")))
+	texCoordAttrib := uint32(gl.GetAttribLocation(program, gl.Str("vertTexCoord\x00")))
+
+	gl.EnableVertexAttribArray(vertAttrib)
+	gl.VertexAttribPointer(vertAttrib, 3, gl.FLOAT, false, 5*4, gl.PtrOffset(0))
+
+	gl.EnableVertexAttribArray(texCoordAttrib)
+	gl.VertexAttribPointer(texCoordAttrib, 2, gl.FLOAT, false, 5*4, gl.PtrOffset(3*4))
+
+	texture, err := s.loadTexture("d6.png")
+	if err != nil {
+		return err
+	}
+	s.texture = texture
+
+	s.input.free = s.camera.orbiting
+	if err := s.input.attach(&s.cameraSettings); err != nil {
+		return err
+	}
+	s.camera.restore(s.kept, &s.input)
+
+	s.models = []mgl32.Mat4{
+		mgl32.Translate3D(0, 0, 0),
+		mgl32.Translate3D(3.53, 0, 3.53),
+		mgl32.Translate3D(-3.53, 0, 3.53),
+		mgl32.Translate3D(3.53, 0, -3.53),
+		mgl32.Translate3D(-3.53, 0, -3.53),
+
+		mgl32.Translate3D(5, 0, 0),
+		mgl32.Translate3D(-5, 0, 0),
+		mgl32.Translate3D(0, 0, 5),
+		mgl32.Translate3D(0, 0, -5),
+	}
+
+	s.lastHorizontalAngle, s.lastVerticalAngle = s.camera.fps.HorizontalAngle, s.camera.fps.VerticalAngle
+
+	return nil
+}
+
+// SaveState - Keeps the camera's view and the bookmarks
+func (s *CubesRotating) SaveState() (json.RawMessage, error) {
+	return json.Marshal(s.camera.state())
+}
+
+// RestoreState - Starts the camera where it was kept, with the kept bookmarks
+func (s *CubesRotating) RestoreState(state json.RawMessage) error {
+	kept, err := parseRigState(state)
+	if err != nil {
+		return err
+	}
+	s.kept = kept
+	return nil
+}
+
+// SetWindow - Makes the scene read its input from window
+func (s *CubesRotating) SetWindow(window controls.Window) {
+	s.input.window = window
+}
+
+// SetInput - Makes the scene read its input from device, which the caller polls before every update
+func (s *CubesRotating) SetInput(device *controls.Device) {
+	s.input.device = device
+}
+
+// Update - Moves the camera according to the input of the last dt seconds
+func (s *CubesRotating) Update(dt float64) {
+	s.input.poll()
+	s.camera.update(&s.input, dt)
+	if s.input.actions.Active(toggleWireframe) {
+		s.wireframe = !s.wireframe
+	}
+
+	fps := &s.camera.fps
+	if !s.camera.orbiting && (fps.HorizontalAngle != s.lastHorizontalAngle || fps.VerticalAngle != s.lastVerticalAngle) {
+		log.Printf("Angles: %f, %f\n", fps.HorizontalAngle, fps.VerticalAngle)
+		s.lastHorizontalAngle, s.lastVerticalAngle = fps.HorizontalAngle, fps.VerticalAngle
+	}
+}
+
+// Render - Draws one frame
+func (s *CubesRotating) Render(alpha float64) {
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+	if s.wireframe {
+		gl.PolygonMode(gl.FRONT_AND_BACK, gl.LINE)
+		defer gl.PolygonMode(gl.FRONT_AND_BACK, gl.FILL)
+	}
+
+	gl.UseProgram(s.program)
+	gl.BindVertexArray(s.vao)
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, s.texture)
+
+	// The aspect can change between updates when the window is resized
+	projection := s.project(s.camera.projection(s.aspect()))
+	s.matrices.Set(projection, s.camera.view(alpha))
+
+	for i := range s.models {
+		gl.UniformMatrix4fv(s.modelUniform, 1, false, &s.models[i][0])
+		gl.DrawArrays(gl.TRIANGLES, 0, 6*2*3)
+	}
+}
+
+// Shutdown - Releases the GL objects of the scene and unhooks the window's input
+func (s *CubesRotating) Shutdown() {
+	s.input.detach()
+
+	helpers.DeleteBuffer(s.vbo)
+	helpers.DeleteVertexArray(s.vao)
+	s.matrices.Delete()
+	s.releaseShaders()
+	s.releaseTexture(s.texture)
+	gl.Disable(gl.CULL_FACE)
+	gl.Disable(gl.DEPTH_TEST)
+}
+
+var diceVertices = []float32{
+	//1
+	1, 1, 1, 0.0, 0.0,
+	1, 1, -1, 0.0, 0.335973,
+	-1, 1, -1, 0.335973, 0.335973,
+	1, 1, 1, 0.0, 0.0,
+	-1, 1, -1, 0.335973, 0.335973,
+	-1, 1, 1, 0.335973, 0.0,
+	//2
+	1, 1, -1, 0.335973, 0.0, // Top Left
+	1, -1, -1, 0.335973, 0.335973, // Bottom Left
+	-1, -1, -1, 0.668104, 0.335973, // Bottom Right
+	1, 1, -1, 0.335973, 0.0, // Top Left
+	-1, -1, -1, 0.668104, 0.335973, // Bottom Right
+	-1, 1, -1, 0.668104, 0.0, // Top Right
+	//3
+	1, 1, 1, 0.668104, 0.0, // Top Left
+	1, -1, -1, 1, 0.335973, // Bottom Right
+	1, 1, -1, 1.0, 0.0, // Top Right
+	1, -1, -1, 1.0, 0.335973, // Bottom Right
+	1, 1, 1, 0.668104, 0.0, // Top Left
+	1, -1, 1, 0.668104, 0.335973, // Bottom Left
+	//4
+	-1, -1, -1, 0.0, 0.335973,
+	-1, -1, 1, 0.0, 0.668104,
+	-1, 1, 1, 0.335973, 0.668104,
+	-1, -1, -1, 0.0, 0.336048,
+	-1, 1, 1, 0.335973, 0.668104,
+	-1, 1, -1, 0.335973, 0.335903,
+	//5
+	-1, 1, 1, 0.335973, 0.335973, // Top Left
+	-1, -1, 1, 0.335973, 0.668104, // Bottom Left
+	1, -1, 1, 0.668104, 0.668104, // Bottom Right
+	1, 1, 1, 0.668104, 0.335973, // Top Right
+	-1, 1, 1, 0.335973, 0.335973, // Top Left
+	1, -1, 1, 0.668104, 0.668104, // Bottom Right
+	//6
+	1, -1, 1, 0.668104, 0.668104, // Bottom Left
+	-1, -1, -1, 1.0, 0.335973, // Top Right
+	1, -1, -1, 0.668104, 0.335973, // Top Left
+	1, -1, 1, 0.668104, 0.668104, // Bottom Left
+	-1, -1, 1, 1.0, 0.668104, // Bottom Right
+	-1, -1, -1, 1, 0.335973, // Top Right
+}
@@ -0,0 +1,167 @@
+package scenes
+
+import (
+	"github.com/thegrandpackard/gogl/camera"
+	"github.com/thegrandpackard/gogl/controls"
+)
+
+// Actions of the scenes with a moving camera, bound with the bindings setting
+const (
+	moveForward     = "MoveForward"
+	moveBackward    = "MoveBackward"
+	strafeLeft      = "StrafeLeft"
+	strafeRight     = "StrafeRight"
+	zoom            = "Zoom"
+	rotate          = "Rotate"
+	pan             = "Pan"
+	toggleCamera    = "ToggleCamera"
+	frameModel      = "FrameModel"
+	toggleWireframe = "ToggleWireframe"
+	addKeyframe     = "AddKeyframe"
+	clearPath       = "ClearPath"
+	playPath        = "PlayPath"
+	savePath        = "SavePath"
+	loadPath        = "LoadPath"
+)
+
+var sceneActions = controls.Actions{
+	moveForward:     controls.Hold,
+	moveBackward:    controls.Hold,
+	strafeLeft:      controls.Hold,
+	strafeRight:     controls.Hold,
+	zoom:            controls.Hold,
+	rotate:          controls.Hold,
+	pan:             controls.Hold,
+	toggleCamera:    controls.Press,
+	frameModel:      controls.Press,
+	toggleWireframe: controls.Press,
+	addKeyframe:     controls.Press,
+	clearPath:       controls.Press,
+	playPath:        controls.Press,
+	savePath:        controls.Press,
+	loadPath:        controls.Press,
+}
+
+// arrowBindings - Moving with the arrow keys, and what every scene shares
+func arrowBindings() controls.Bindings {
+	return bookmarkBindings(controls.Bindings{
+		moveForward:     {"Up"},
+		moveBackward:    {"Down"},
+		strafeLeft:      {"Left"},
+		strafeRight:     {"Right"},
+		zoom:            {"ScrollY"},
+		rotate:          {"MouseLeft"},
+		pan:             {"MouseRight", "MouseMiddle"},
+		toggleCamera:    {"C", "PadY"},
+		frameModel:      {"F", "PadRightStick"},
+		toggleWireframe: {"Ctrl+L", "PadBack"},
+		addKeyframe:     {"K"},
+		clearPath:       {"Shift+K"},
+		playPath:        {"P", "PadStart"},
+		savePath:        {"Ctrl+S"},
+		loadPath:        {"Ctrl+O"},
+	})
+}
+
+// wasdBindings - Moving with WASD as well as the arrow keys
+func wasdBindings() controls.Bindings {
+	bindings := arrowBindings()
+	bindings[moveForward] = append(bindings[moveForward], "W")
+	bindings[moveBackward] = append(bindings[moveBackward], "S")
+	bindings[strafeLeft] = append(bindings[strafeLeft], "A")
+	bindings[strafeRight] = append(bindings[strafeRight], "D")
+	return bindings
+}
+
+// windowInput - Turns the input of a window and a gamepad into camera input. While the cursor
+// steers the camera it is hidden and disabled, so the window reports unbounded motion instead of the
+// cursor stopping at the edge of the screen or losing motion to recentering; when it is free it
+// moves normally and drags with the Rotate and Pan actions. The left stick moves, the right stick
+// turns and the triggers zoom.
+//
+// Everything is read through a device, which the app hands over with SetInput and polls before
+// every update so it can record and replay. Without one the scene reads the window itself.
+type windowInput struct {
+	window  controls.Window
+	device  *controls.Device
+	actions *controls.Map
+
+	// owned is set when the scene made the device itself and has to poll it
+	owned bool
+
+	free         bool
+	lastX, lastY float64
+	// resync makes the next snapshot measure from wherever the cursor is, after a mode change
+	resync bool
+}
+
+// attach - Starts reading the device, or the window and the first gamepad, as settings say
+func (w *windowInput) attach(settings *cameraSettings) error {
+	if w.device == nil {
+		w.device, w.owned = controls.NewDevice(w.window), true
+		w.device.Attach()
+	}
+	w.device.ConfigureGamepad(settings.Deadzone, settings.ResponseCurve)
+
+	actions, err := controls.NewMap(w.device, sceneActions, settings.Bindings)
+	if err != nil {
+		return err
+	}
+	w.actions = actions
+	w.setFree(w.free)
+	return nil
+}
+
+func (w *windowInput) detach() {
+	if w.owned {
+		w.device.Detach()
+	}
+	if w.window != nil {
+		w.window.SetCursorFree(true)
+	}
+}
+
+// setFree - Lets the cursor move freely, or makes it steer the camera again
+func (w *windowInput) setFree(free bool) {
+	w.free = free
+	// Measure from wherever the mode change leaves the cursor, so switching does not jump
+	w.resync = true
+	if w.window != nil {
+		w.window.SetCursorFree(free)
+	}
+}
+
+// poll - Samples the actions, once per update before anything reads them
+func (w *windowInput) poll() {
+	if w.owned {
+		w.device.Poll()
+	}
+	w.actions.Poll()
+}
+
+// snapshot - The camera input of the last poll
+func (w *windowInput) snapshot() camera.Input {
+	var input camera.Input
+
+	xpos, ypos := w.device.Cursor()
+	if !w.resync {
+		input.LookX, input.LookY = xpos-w.lastX, ypos-w.lastY
+	}
+	w.lastX, w.lastY, w.resync = xpos, ypos, false
+	if w.free {
+		input.Rotating = w.actions.Down(rotate)
+		input.Panning = w.actions.Down(pan)
+	}
+
+	input.Forward = w.actions.Down(moveForward)
+	input.Backward = w.actions.Down(moveBackward)
+	input.Left = w.actions.Down(strafeLeft)
+	input.Right = w.actions.Down(strafeRight)
+	input.Zoom = w.actions.Value(zoom)
+
+	// Stick down is positive like the cursor, forward is negative
+	input.MoveX, input.MoveY = w.device.PadAxis(controls.LeftX), -w.device.PadAxis(controls.LeftY)
+	input.TurnX, input.TurnY = w.device.PadAxis(controls.RightX), w.device.PadAxis(controls.RightY)
+	input.ZoomAxis = w.device.PadAxis(controls.RightTrigger) - w.device.PadAxis(controls.LeftTrigger)
+	return input
+}
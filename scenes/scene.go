@@ -0,0 +1,122 @@
+// Package scenes holds the rendering of every demo as an app.App, so it can be driven by the
+// window runner, by the headless runner or by the golden image harness. Scenes render into
+// whatever framebuffer and viewport is bound.
+package scenes
+
+import (
+	// Textures are PNG files
+	_ "image/png"
+	"path/filepath"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// size - The dimensions scenes derive their projection from, and the part of them being rendered
+type size struct {
+	Width, Height int
+
+	tile *helpers.Tile
+}
+
+// Resize - Called by the runners whenever the framebuffer changes size
+func (s *size) Resize(width, height int) {
+	s.Width, s.Height = width, height
+}
+
+// SetTile - Makes Render draw only tile of an image of the size last given to Resize, into the
+// bound viewport. Nil draws the whole image again.
+func (s *size) SetTile(tile *helpers.Tile) {
+	s.tile = tile
+}
+
+// project - Narrows a projection of the whole image to the tile being rendered
+func (s size) project(projection mgl32.Mat4) mgl32.Mat4 {
+	if s.tile == nil {
+		return projection
+	}
+	return s.tile.Projection(projection)
+}
+
+func (s size) aspect() float32 {
+	if s.Height == 0 {
+		return 1
+	}
+	return float32(s.Width) / float32(s.Height)
+}
+
+// shaders - Compiles the programs of a scene, through the program cache the runner hands over
+// when there is one. The cube scenes use variants of the shared base shaders with the feature
+// keywords each one needs.
+type shaders struct {
+	cache    *helpers.ProgramCache
+	variants *helpers.ShaderVariants
+}
+
+// SetProgramCache - Loads linked programs from cache and stores new ones in it
+func (s *shaders) SetProgramCache(cache *helpers.ProgramCache) {
+	s.cache = cache
+}
+
+// variant - The program of the base shaders with keywords defined, reading its matrices from a
+// uniform block where the context allows
+func (s *shaders) variant(keywords ...string) (uint32, error) {
+	if s.variants == nil {
+		s.variants = helpers.NewShaderVariants(helpers.BaseVertexShader, helpers.BaseFragmentShader)
+		s.variants.Cache = s.cache
+	}
+	if helpers.MatrixBlockSupported() {
+		keywords = append(keywords, helpers.MatrixBlock)
+	}
+	return s.variants.Program(keywords...)
+}
+
+// newProgram - A program of a scene's own shaders, released with helpers.DeleteProgram
+func (s *shaders) newProgram(vertexShaderSource, fragmentShaderSource string) (uint32, error) {
+	if s.cache != nil {
+		return s.cache.NewProgram(vertexShaderSource, fragmentShaderSource)
+	}
+	return helpers.NewProgram(vertexShaderSource, fragmentShaderSource)
+}
+
+// releaseShaders - Deletes every program variant returned
+func (s *shaders) releaseShaders() {
+	if s.variants != nil {
+		s.variants.Delete()
+	}
+}
+
+// assets - Where a scene looks for textures and models. Empty means the working directory,
+// which is where the demos have always been run from.
+type assets struct {
+	AssetDir string
+
+	// textures is shared between scenes when set, otherwise every scene loads its own
+	textures *helpers.TextureCache
+}
+
+// SetTextureCache - Loads textures through cache, which keeps them alive after Shutdown
+func (a *assets) SetTextureCache(cache *helpers.TextureCache) {
+	a.textures = cache
+}
+
+func (a assets) path(file string) string {
+	return filepath.Join(a.AssetDir, file)
+}
+
+func (a assets) loadTexture(file string) (uint32, error) {
+	if a.textures != nil {
+		return a.textures.Texture(a.path(file))
+	}
+	return helpers.NewTexture(a.path(file))
+}
+
+// releaseTexture - Deletes a texture from loadTexture unless the cache owns it
+func (a assets) releaseTexture(texture uint32) {
+	if a.textures == nil {
+		helpers.DeleteTexture(texture)
+	}
+}
+
+// staticCamera - The fixed view most demos use
+var staticCamera = mgl32.LookAt(4, 3, 3, 0, 0, 0, 0, 1, 0)
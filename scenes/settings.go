@@ -0,0 +1,177 @@
+package scenes
+
+import (
+	"fmt"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/thegrandpackard/gogl/camera"
+	"github.com/thegrandpackard/gogl/controls"
+	"github.com/thegrandpackard/gogl/gl"
+)
+
+// sceneSettings - What the config file can change in every scene
+type sceneSettings struct {
+	ClearColor [4]float32 `json:"clearColor"`
+}
+
+var defaultSceneSettings = sceneSettings{
+	ClearColor: [4]float32{0.0, 0.0, 0.4, 0},
+}
+
+// Settings - Exposes the settings to the config file
+func (s *sceneSettings) Settings() interface{} {
+	return s
+}
+
+// Validate - Checks the values the config file set
+func (s *sceneSettings) Validate() error {
+	for _, c := range s.ClearColor {
+		if c < 0 || c > 1 {
+			return fmt.Errorf("clearColor: components must be between 0 and 1, got %v", s.ClearColor)
+		}
+	}
+	return nil
+}
+
+func (s *sceneSettings) clear() {
+	gl.ClearColor(s.ClearColor[0], s.ClearColor[1], s.ClearColor[2], s.ClearColor[3])
+}
+
+// Camera controllers a scene can start with
+const (
+	fpsController   = "fps"
+	orbitController = "orbit"
+)
+
+// cameraSettings - What the config file can change in the scenes with a moving camera
+type cameraSettings struct {
+	sceneSettings
+
+	// Where the camera starts, angles in radians
+	Position        [3]float32 `json:"position"`
+	HorizontalAngle float64    `json:"horizontalAngle"`
+	VerticalAngle   float64    `json:"verticalAngle"`
+	InitialFoV      float32    `json:"initialFoV"`
+
+	// Speed is in units per second, Sensitivity in degrees per pixel and ScrollSpeed in steps of
+	// 5 degrees of field of view per scroll wheel notch. Smoothing is how many seconds mouse
+	// look takes to cover about two thirds of the cursor movement, zero for none.
+	Speed       float64 `json:"speed"`
+	Sensitivity float64 `json:"sensitivity"`
+	Smoothing   float64 `json:"smoothing"`
+	ScrollSpeed float64 `json:"scrollSpeed"`
+
+	// Controller is the camera the scene starts with, fps or orbit. Damping is how many seconds
+	// the orbit camera takes to cover about two thirds of the remaining motion.
+	Controller string  `json:"controller"`
+	Damping    float64 `json:"damping"`
+
+	// TurnSpeed is in degrees per second of a fully deflected gamepad stick. Deadzone is the
+	// fraction of a stick's travel that is ignored and ResponseCurve the exponent of the rest,
+	// 1 for a linear response.
+	TurnSpeed     float64 `json:"turnSpeed"`
+	Deadzone      float64 `json:"deadzone"`
+	ResponseCurve float64 `json:"responseCurve"`
+
+	// Path is the file camera paths are saved to and loaded from. Paths recorded in the scene
+	// follow PathSpline, catmull-rom or bezier, at PathSpeed units per second, and start over
+	// at the end when PathLoop is set. PathTurnWeight is the units a radian of turning counts
+	// as, so keyframes recorded in one place take time to turn between.
+	Path           string  `json:"path"`
+	PathSpline     string  `json:"pathSpline"`
+	PathSpeed      float64 `json:"pathSpeed"`
+	PathTurnWeight float64 `json:"pathTurnWeight"`
+	PathLoop       bool    `json:"pathLoop"`
+
+	// Bindings maps the actions MoveForward, MoveBackward, StrafeLeft, StrafeRight, Zoom,
+	// Rotate, Pan, ToggleCamera, FrameModel, ToggleWireframe, AddKeyframe, ClearPath, PlayPath,
+	// SavePath, LoadPath, Bookmark1 to Bookmark9 and SetBookmark1 to SetBookmark9 to keys, mouse
+	// buttons and scroll axes. Actions the config file leaves out keep their defaults.
+	Bindings controls.Bindings `json:"bindings"`
+}
+
+// Settings - Exposes the settings to the config file
+func (s *cameraSettings) Settings() interface{} {
+	return s
+}
+
+// Validate - Checks the values the config file set
+func (s *cameraSettings) Validate() error {
+	if err := s.sceneSettings.Validate(); err != nil {
+		return err
+	}
+	if s.InitialFoV <= 0 || s.InitialFoV >= 180 {
+		return fmt.Errorf("initialFoV: must be between 0 and 180 degrees, got %v", s.InitialFoV)
+	}
+	if s.Speed < 0 {
+		return fmt.Errorf("speed: must not be negative, got %v", s.Speed)
+	}
+	if s.Sensitivity < 0 {
+		return fmt.Errorf("sensitivity: must not be negative, got %v", s.Sensitivity)
+	}
+	if s.Smoothing < 0 {
+		return fmt.Errorf("smoothing: must not be negative, got %v", s.Smoothing)
+	}
+	if s.ScrollSpeed < 0 {
+		return fmt.Errorf("scrollSpeed: must not be negative, got %v", s.ScrollSpeed)
+	}
+	if s.Controller != fpsController && s.Controller != orbitController {
+		return fmt.Errorf("controller: must be %q or %q, got %q", fpsController, orbitController, s.Controller)
+	}
+	if s.Damping < 0 {
+		return fmt.Errorf("damping: must not be negative, got %v", s.Damping)
+	}
+	if s.TurnSpeed < 0 {
+		return fmt.Errorf("turnSpeed: must not be negative, got %v", s.TurnSpeed)
+	}
+	if s.Deadzone < 0 || s.Deadzone >= 1 {
+		return fmt.Errorf("deadzone: must be at least 0 and below 1, got %v", s.Deadzone)
+	}
+	if s.ResponseCurve <= 0 {
+		return fmt.Errorf("responseCurve: must be positive, got %v", s.ResponseCurve)
+	}
+	if s.Path == "" {
+		return fmt.Errorf("path: must not be empty")
+	}
+	if _, err := camera.ParseSpline(s.PathSpline); err != nil {
+		return fmt.Errorf("pathSpline: %v", err)
+	}
+	if s.PathSpeed <= 0 {
+		return fmt.Errorf("pathSpeed: must be positive, got %v", s.PathSpeed)
+	}
+	if s.PathTurnWeight < 0 {
+		return fmt.Errorf("pathTurnWeight: must not be negative, got %v", s.PathTurnWeight)
+	}
+	if err := s.Bindings.Check(sceneActions); err != nil {
+		return fmt.Errorf("bindings.%v", err)
+	}
+	return nil
+}
+
+// newCamera - A camera at the configured starting pose
+func (s *cameraSettings) newCamera() camera.Camera {
+	c := camera.Camera{
+		Position:        mgl32.Vec3(s.Position),
+		HorizontalAngle: s.HorizontalAngle,
+		VerticalAngle:   s.VerticalAngle,
+		FieldOfView:     s.InitialFoV,
+		Near:            0.1,
+		Far:             100,
+		Speed:           s.Speed,
+		Sensitivity:     s.Sensitivity,
+		TurnSpeed:       s.TurnSpeed,
+		Smoothing:       s.Smoothing,
+		ZoomSpeed:       5 * s.ScrollSpeed,
+	}
+	c.Reset()
+	return c
+}
+
+// newPath - An empty path with the configured spline, speed and turn weight
+func (s *cameraSettings) newPath() *camera.Path {
+	spline, _ := camera.ParseSpline(s.PathSpline)
+	path := camera.NewPath(spline)
+	path.Speed = s.PathSpeed
+	path.TurnWeight = s.PathTurnWeight
+	return path
+}
@@ -0,0 +1,105 @@
+package scenes
+
+import (
+	"github.com/thegrandpackard/gogl/app"
+	"github.com/thegrandpackard/gogl/gl"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// Triangle - A red triangle drawn directly in clip space
+type Triangle struct {
+	sceneSettings
+	shaders
+	vao, vbo uint32
+	program  uint32
+}
+
+// NewTriangle - Returns the triangle scene
+func NewTriangle() *Triangle {
+	return &Triangle{sceneSettings: defaultSceneSettings}
+}
+
+func init() {
+	app.Register(app.Demo{
+		Name:        "triangle",
+		Title:       "Triangle",
+		Description: "A red triangle drawn directly in clip space",
+		New: func(config app.Config, assetDir string) app.App {
+			return NewTriangle()
+		},
+	})
+}
+
+// Init - Creates the GL objects of the scene
+func (s *Triangle) Init() error {
+	s.clear()
+
+	// Configure the vertex data
+	s.vao = helpers.GenVertexArray()
+	gl.BindVertexArray(s.vao)
+
+	// Configure the vertex and fragment shaders
+	program, err := s.newProgram(triangleVertexShader, triangleFragmentShader)
+	if err != nil {
+		return err
+	}
+	s.program = program
+
+	s.vbo = helpers.GenBuffer()
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(triangleVertices)*4, gl.Ptr(triangleVertices), gl.STATIC_DRAW)
+
+	return nil
+}
+
+// Update - The triangle does not move
+func (s *Triangle) Update(dt float64) {}
+
+// Render - Draws one frame
+func (s *Triangle) Render(alpha float64) {
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+
+	gl.UseProgram(s.program)
+	gl.BindVertexArray(s.vao)
+
+	gl.EnableVertexAttribArray(0)
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.vbo)
+	gl.VertexAttribPointer(0, 3, gl.FLOAT, false, 0, gl.PtrOffset(0))
+	gl.DrawArrays(gl.TRIANGLES, 0, 3)
+	gl.DisableVertexAttribArray(0)
+}
+
+// Shutdown - Releases the GL objects of the scene
+func (s *Triangle) Shutdown() {
+	helpers.DeleteBuffer(s.vbo)
+	helpers.DeleteVertexArray(s.vao)
+	helpers.DeleteProgram(s.program)
+}
+
+var triangleVertices = []float32{
+	-1.0, -1.0, 0.0,
+	1.0, -1.0, 0.0,
+	0.0, 1.0, 0.0,
+}
+
+var triangleVertexShader = `
+#version 330
+
+// Input vertex data, different for all executions of this shader.
+layout(location = 0) in vec3 vertexPosition_modelspace;
+
+void main() {
+    gl_Position.xyz = vertexPosition_modelspace;
+		gl_Position.w = 1.0;
+}
+` + "\x00"
+
+var triangleFragmentShader = `
+#version 330
+
+out vec3 outputColor;
+
+void main() {
+    outputColor = vec3(1,0,0);
+}
+` + "\x00"
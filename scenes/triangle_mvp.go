@@ -0,0 +1,106 @@
+package scenes
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/thegrandpackard/gogl/app"
+	"github.com/thegrandpackard/gogl/gl"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// TriangleMVP - The red triangle seen through a perspective camera
+type TriangleMVP struct {
+	size
+	sceneSettings
+	shaders
+
+	vao, vbo uint32
+	program  uint32
+
+	projectionUniform, viewUniform, modelUniform int32
+}
+
+// NewTriangleMVP - Returns the triangle scene for a width x height target
+func NewTriangleMVP(width, height int) *TriangleMVP {
+	return &TriangleMVP{sceneSettings: defaultSceneSettings, size: size{Width: width, Height: height}}
+}
+
+func init() {
+	app.Register(app.Demo{
+		Name:        "triangle_mvp",
+		Title:       "Triangle in Perspective",
+		Description: "The red triangle seen through a perspective camera",
+		New: func(config app.Config, assetDir string) app.App {
+			return NewTriangleMVP(config.Width, config.Height)
+		},
+	})
+}
+
+// Init - Creates the GL objects of the scene
+func (s *TriangleMVP) Init() error {
+	s.clear()
+
+	// Configure the vertex data
+	s.vao = helpers.GenVertexArray()
+	gl.BindVertexArray(s.vao)
+
+	// Configure the vertex and fragment shaders
+	program, err := s.newProgram(triangleMVPVertexShader, triangleFragmentShader)
+	if err != nil {
+		return err
+	}
+	s.program = program
+
+	s.projectionUniform = gl.GetUniformLocation(program, gl.Str("projection\x00"))
+	s.viewUniform = gl.GetUniformLocation(program, gl.Str("view\x00"))
+	s.modelUniform = gl.GetUniformLocation(program, gl.Str("model\x00"))
+
+	s.vbo = helpers.GenBuffer()
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(triangleVertices)*4, gl.Ptr(triangleVertices), gl.STATIC_DRAW)
+
+	return nil
+}
+
+// Update - The triangle does not move
+func (s *TriangleMVP) Update(dt float64) {}
+
+// Render - Draws one frame
+func (s *TriangleMVP) Render(alpha float64) {
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+
+	gl.UseProgram(s.program)
+	gl.BindVertexArray(s.vao)
+
+	projection := s.project(mgl32.Perspective(mgl32.DegToRad(45.0), s.aspect(), 0.1, 10))
+	model := mgl32.Ident4()
+	gl.UniformMatrix4fv(s.projectionUniform, 1, false, &projection[0])
+	gl.UniformMatrix4fv(s.viewUniform, 1, false, &staticCamera[0])
+	gl.UniformMatrix4fv(s.modelUniform, 1, false, &model[0])
+
+	gl.EnableVertexAttribArray(0)
+	gl.BindBuffer(gl.ARRAY_BUFFER, s.vbo)
+	gl.VertexAttribPointer(0, 3, gl.FLOAT, false, 0, gl.PtrOffset(0))
+	gl.DrawArrays(gl.TRIANGLES, 0, 3)
+	gl.DisableVertexAttribArray(0)
+}
+
+// Shutdown - Releases the GL objects of the scene
+func (s *TriangleMVP) Shutdown() {
+	helpers.DeleteBuffer(s.vbo)
+	helpers.DeleteVertexArray(s.vao)
+	helpers.DeleteProgram(s.program)
+}
+
+var triangleMVPVertexShader = `
+#version 330
+
+uniform mat4 projection;
+uniform mat4 view;
+uniform mat4 model;
+
+in vec3 vert;
+
+void main() {
+    gl_Position = projection * view * model * vec4(vert, 1);
+}
+` + "\x00"
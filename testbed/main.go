@@ -0,0 +1,178 @@
+// Runs any registered demo by name. Run it from the repository root so the demos find their assets:
+//
+//	go run ./testbed list
+//	go run ./testbed run cube_keyboard_mouse
+//	go run ./testbed -samples 0 -gl 3.3 run cube_textured
+//
+// Settings of the demos can be set from a JSON file and overridden one by one, keys without a demo
+// name apply to the demo run first:
+//
+//	go run ./testbed -config testbed/settings.json run cubes_rotating -set speed=10 -set cube_textured.clearColor=[0,0,0,1]
+//
+// Page Up and Page Down switch to the previous and next demo in the same window, F1 lists them.
+// F12 saves a screenshot and Shift+F12 captures the next frames to a GIF. A recording can be
+// turned into a GIF or a PNG sequence rendered at its own time step:
+//
+//	go run ./testbed -replay orbit.input -capture orbit.gif -capture-frames 300 run cubes_rotating
+//
+// The window's placement, the demo running last and the demos' cameras and bookmarks are kept in
+// a per-user state file, and run without a demo name starts where the last run stopped. Recording
+// and replaying input and capturing frames leave the state alone, so they always start from the
+// configured cameras.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/thegrandpackard/gogl/app"
+	// Registers the demos
+	_ "github.com/thegrandpackard/gogl/scenes"
+	"github.com/thegrandpackard/gogl/window"
+)
+
+// overrides - Collects every -set flag
+type overrides []string
+
+func (o *overrides) String() string {
+	return strings.Join(*o, " ")
+}
+
+func (o *overrides) Set(value string) error {
+	*o = append(*o, value)
+	return nil
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "usage: testbed [flags] list\n       testbed [flags] run [DEMO] [flags]\n\nflags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	config := app.DefaultConfig("")
+	config.RegisterFlags(flag.CommandLine)
+	assetRoot := flag.String("assets", ".", "directory holding the asset folder of every demo")
+	settingsFile := flag.String("config", "", "JSON file with the settings of the demos")
+	stateFile := flag.String("state", app.DefaultStateFile(), "`file` keeping the window, the demo and the cameras between runs, empty to keep nothing")
+	var sets overrides
+	flag.Var(&sets, "set", "override a setting as [demo.]key=value, may be repeated")
+	flag.Usage = usage
+	flag.Parse()
+
+	switch flag.Arg(0) {
+	case "list":
+		for _, demo := range app.Demos() {
+			fmt.Printf("%-20s %s\n", demo.Name, demo.Description)
+		}
+
+	case "run":
+		// Flags may come before and after the demo name
+		if err := flag.CommandLine.Parse(flag.Args()[1:]); err != nil {
+			os.Exit(2)
+		}
+		start := flag.Arg(0)
+		if _, ok := app.LookupDemo(start); start != "" && !ok {
+			log.Fatalf("unknown demo %q, see testbed list", start)
+		}
+		if flag.NArg() > 1 {
+			if err := flag.CommandLine.Parse(flag.Args()[1:]); err != nil {
+				os.Exit(2)
+			}
+			if flag.NArg() > 0 {
+				log.Fatalf("unexpected arguments %q", flag.Args())
+			}
+		}
+
+		state, err := loadState(*stateFile, &config)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if _, ok := app.LookupDemo(state.Demo); start == "" && ok {
+			start = state.Demo
+		}
+
+		settings, err := loadSettings(*settingsFile, sets, start, config)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		switcher, err := app.NewSwitcher(app.Demos(), start, config, *assetRoot)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		switcher.Settings = settings
+		switcher.State = config.State
+		config.Title = switcher.Title()
+		err = window.Run(switcher, config)
+		if config.State != nil {
+			if saveErr := config.State.Save(*stateFile); saveErr != nil {
+				log.Printf("failed to save the state: %v", saveErr)
+			}
+		}
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+// loadState - Reads the state file into config.State and opens the window at its last size,
+// unless a flag sets the size. Without a state file, and while recording, replaying or
+// capturing, the state is empty and config.State stays nil so nothing is kept.
+func loadState(file string, config *app.Config) (*app.State, error) {
+	if file == "" || config.Record != "" || config.Replay != "" || config.Capture != "" {
+		return &app.State{}, nil
+	}
+	state, err := app.LoadState(file)
+	if err != nil {
+		return nil, err
+	}
+
+	sized := false
+	flag.Visit(func(f *flag.Flag) {
+		sized = sized || f.Name == "width" || f.Name == "height"
+	})
+	if !sized {
+		state.ApplyWindowSize(config)
+	}
+	config.State = state
+	return state, nil
+}
+
+// loadSettings - Reads the settings file when there is one, applies the overrides and checks the
+// result against every demo
+func loadSettings(file string, sets overrides, start string, config app.Config) (app.SettingsFile, error) {
+	settings := app.SettingsFile{}
+	if file != "" {
+		var err error
+		if settings, err = app.LoadSettings(file); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, set := range sets {
+		if key := strings.SplitN(set, "=", 2)[0]; !strings.Contains(key, ".") {
+			if start == "" {
+				return nil, fmt.Errorf("setting %q needs a demo name when no demo is given to run", set)
+			}
+			set = start + "." + set
+		}
+		if err := settings.Set(set); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := settings.Check(config); err != nil {
+		if file != "" {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+		return nil, err
+	}
+	return settings, nil
+}
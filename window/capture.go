@@ -0,0 +1,148 @@
+package window
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+	"github.com/thegrandpackard/gogl/app"
+	"github.com/thegrandpackard/gogl/capture"
+	"github.com/thegrandpackard/gogl/gl"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// captures - Reads frames back for the capture hotkeys and Config.Capture:
+//
+//	F12        save the next frame as a PNG
+//	Shift+F12  capture the next CaptureFrames frames to a GIF, again to stop early
+//
+// While capturing every frame runs exactly one update, so the capture plays back at the
+// simulated speed however slowly the frames render and read back.
+type captures struct {
+	dir      string
+	frames   int
+	timeStep float64
+
+	// exit - Close the window once the capture is written, it was what the run was for
+	exit bool
+
+	screenshot bool
+	recorder   *capture.Recorder
+	file       string
+	next       glfw.KeyCallback
+}
+
+func newCaptures(window *glfw.Window, config app.Config) (*captures, error) {
+	c := &captures{dir: config.ScreenshotDir, frames: config.CaptureFrames, timeStep: config.TimeStep}
+	if c.dir == "" {
+		c.dir = "."
+	}
+	if config.Capture != "" {
+		if err := c.start(config.Capture); err != nil {
+			return nil, err
+		}
+		c.exit = true
+	}
+	c.next = window.SetKeyCallback(c.keyCallback)
+	return c, nil
+}
+
+func (c *captures) keyCallback(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+	if c.next != nil {
+		c.next(w, key, scancode, action, mods)
+	}
+	if action != glfw.Press || key != glfw.KeyF12 {
+		return
+	}
+
+	switch {
+	case mods&glfw.ModShift == 0:
+		c.screenshot = true
+	case c.recorder != nil:
+		c.stop()
+	default:
+		file := filepath.Join(c.dir, "capture_"+time.Now().Format("20060102_150405")+".gif")
+		if err := c.start(file); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// recording - Whether frames are being captured, which makes the loop run one update per frame
+func (c *captures) recording() bool {
+	return c.recorder != nil
+}
+
+func (c *captures) start(file string) error {
+	if c.timeStep <= 0 {
+		return fmt.Errorf("capturing needs a fixed time step")
+	}
+	if c.frames <= 0 {
+		return fmt.Errorf("capturing needs at least one frame, got %d", c.frames)
+	}
+	recorder, err := capture.NewRecorder(file, c.timeStep)
+	if err != nil {
+		return err
+	}
+	c.recorder, c.file = recorder, file
+	log.Printf("capturing %d frames to %s", c.frames, file)
+	return nil
+}
+
+// stop - Writes what was captured
+func (c *captures) stop() {
+	recorder := c.recorder
+	c.recorder = nil
+	written, err := recorder.Close()
+	if err != nil {
+		log.Printf("failed to write %s: %v", c.file, err)
+		return
+	}
+	log.Printf("captured %d frames to %s", recorder.Frames(), written)
+}
+
+// frame - Reads the frame just rendered back when it is wanted, before it is swapped out of the
+// back buffer. It returns false once a capture that ends the run is written.
+func (c *captures) frame(window *glfw.Window) bool {
+	if !c.screenshot && c.recorder == nil {
+		return true
+	}
+	width, height := window.GetFramebufferSize()
+	if width <= 0 || height <= 0 {
+		return true
+	}
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, 0)
+	img := helpers.ReadPixels(0, 0, width, height)
+
+	if c.screenshot {
+		c.screenshot = false
+		if file, err := capture.Screenshot(c.dir, img); err != nil {
+			log.Printf("failed to save a screenshot: %v", err)
+		} else {
+			log.Printf("saved %s", file)
+		}
+	}
+
+	if c.recorder == nil {
+		return true
+	}
+	if err := c.recorder.Add(img); err != nil {
+		log.Printf("capture stopped: %v", err)
+		c.stop()
+		return !c.exit
+	}
+	if c.recorder.Frames() == c.frames {
+		c.stop()
+		return !c.exit
+	}
+	return true
+}
+
+// close - Writes a capture the window was closed in the middle of
+func (c *captures) close() {
+	if c.recorder != nil {
+		c.stop()
+	}
+}
@@ -0,0 +1,50 @@
+package window
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+	"github.com/thegrandpackard/gogl/gl"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+// createWindow - Creates a window with the first profile the driver accepts, makes its context
+// current and initializes the GL binding. Hints other than the context version, such as
+// samples, are taken from whatever the caller set beforehand.
+func createWindow(width, height int, title string, profiles []helpers.ContextProfile) (*glfw.Window, error) {
+	var failures []string
+	for _, profile := range profiles {
+		glfw.WindowHint(glfw.ContextVersionMajor, profile.Major)
+		glfw.WindowHint(glfw.ContextVersionMinor, profile.Minor)
+		if profile.Core {
+			glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
+			glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
+		} else {
+			glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.False)
+			glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLAnyProfile)
+		}
+
+		window, err := glfw.CreateWindow(width, height, title, nil, nil)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%v: %v", profile, err))
+			continue
+		}
+		window.MakeContextCurrent()
+
+		// The binding refuses to load when the context lacks functions it requires
+		if err := gl.Init(); err != nil {
+			failures = append(failures, fmt.Sprintf("%v: %v", profile, err))
+			glfw.DetachCurrentContext()
+			window.Destroy()
+			continue
+		}
+
+		helpers.Caps = helpers.DetectCapabilities(profile)
+		log.Printf("created OpenGL %d.%d context (requested %v, GLSL %d) on %s",
+			helpers.Caps.Major, helpers.Caps.Minor, profile, helpers.Caps.GLSLVersion, helpers.Caps.Renderer)
+		return window, nil
+	}
+	return nil, fmt.Errorf("failed to create an OpenGL context: %s; %s", strings.Join(failures, "; "), helpers.RebuildHint())
+}
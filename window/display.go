@@ -0,0 +1,224 @@
+package window
+
+import (
+	"log"
+	"time"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+	"github.com/thegrandpackard/gogl/app"
+)
+
+// frameCaps - What the frame cap hotkey cycles through
+var frameCaps = []float64{0, 30, 60, 120, 144}
+
+// display - Applies app.Display to a window and handles the hotkeys changing it:
+//
+//	F11        toggle fullscreen in the configured mode, borderless when that is windowed
+//	Alt+Enter  toggle exclusive fullscreen
+//	F10        move to the next monitor
+//	F9         toggle vsync
+//	F8         cycle the frame cap
+type display struct {
+	app.Display
+	window *glfw.Window
+
+	// The windowed placement to return to when leaving fullscreen
+	windowedX, windowedY          int
+	windowedWidth, windowedHeight int
+	fullscreen                    app.FullscreenMode
+
+	nextFrame float64
+}
+
+func newDisplay(window *glfw.Window, settings app.Display) *display {
+	d := &display{Display: settings, window: window}
+	d.windowedX, d.windowedY = window.GetPos()
+	d.windowedWidth, d.windowedHeight = window.GetSize()
+	if settings.Fullscreen != app.Windowed {
+		d.setFullscreen(settings.Fullscreen)
+	}
+	d.setVSync(settings.VSync)
+	return d
+}
+
+func (d *display) monitor() *glfw.Monitor {
+	monitors := glfw.GetMonitors()
+	if len(monitors) == 0 {
+		return nil
+	}
+	if d.Monitor < 0 || d.Monitor >= len(monitors) {
+		log.Printf("monitor %d does not exist, using the primary one", d.Monitor)
+		d.Monitor = 0
+	}
+	return monitors[d.Monitor]
+}
+
+// videoMode - The supported mode of monitor closest to the configured one
+func (d *display) videoMode(monitor *glfw.Monitor) *glfw.VidMode {
+	current := monitor.GetVideoMode()
+	wanted := d.VideoMode
+	if wanted.Width == 0 || wanted.Height == 0 {
+		wanted.Width, wanted.Height = current.Width, current.Height
+	}
+	if wanted.RefreshRate == 0 {
+		wanted.RefreshRate = current.RefreshRate
+	}
+
+	best := current
+	bestScore := -1
+	for _, mode := range monitor.GetVideoModes() {
+		score := abs(mode.Width-wanted.Width) + abs(mode.Height-wanted.Height)
+		score = score*1000 + abs(mode.RefreshRate-wanted.RefreshRate)
+		if bestScore < 0 || score < bestScore {
+			best, bestScore = mode, score
+		}
+	}
+	return best
+}
+
+func (d *display) setFullscreen(mode app.FullscreenMode) {
+	monitor := d.monitor()
+	if mode != app.Windowed && monitor == nil {
+		log.Printf("no monitor to go fullscreen on")
+		mode = app.Windowed
+	}
+
+	if d.fullscreen == app.Windowed && mode != app.Windowed {
+		d.windowedX, d.windowedY = d.window.GetPos()
+		d.windowedWidth, d.windowedHeight = d.window.GetSize()
+	}
+
+	switch mode {
+	case app.Windowed:
+		if d.fullscreen != app.Windowed {
+			d.window.SetMonitor(nil, d.windowedX, d.windowedY, d.windowedWidth, d.windowedHeight, 0)
+		}
+	case app.Borderless:
+		current := monitor.GetVideoMode()
+		d.window.SetMonitor(monitor, 0, 0, current.Width, current.Height, current.RefreshRate)
+	case app.Exclusive:
+		videoMode := d.videoMode(monitor)
+		d.window.SetMonitor(monitor, 0, 0, videoMode.Width, videoMode.Height, videoMode.RefreshRate)
+	}
+	d.fullscreen = mode
+
+	// Some platforms forget the swap interval when the window changes monitor
+	d.setVSync(d.VSync)
+
+	if mode == app.Windowed {
+		log.Printf("windowed %dx%d", d.windowedWidth, d.windowedHeight)
+	} else {
+		width, height := d.window.GetSize()
+		log.Printf("%v fullscreen %dx%d on %s", mode, width, height, monitor.GetName())
+	}
+}
+
+func (d *display) toggleFullscreen(mode app.FullscreenMode) {
+	if d.fullscreen == mode {
+		d.setFullscreen(app.Windowed)
+	} else {
+		d.setFullscreen(mode)
+	}
+}
+
+func (d *display) nextMonitor() {
+	monitors := glfw.GetMonitors()
+	if len(monitors) < 2 {
+		return
+	}
+	d.Monitor = (d.Monitor + 1) % len(monitors)
+
+	if d.fullscreen != app.Windowed {
+		d.setFullscreen(d.fullscreen)
+		return
+	}
+
+	// Center the window on the new monitor, and come back there from fullscreen
+	monitor := monitors[d.Monitor]
+	x, y := monitor.GetPos()
+	current := monitor.GetVideoMode()
+	width, height := d.window.GetSize()
+	d.window.SetPos(x+(current.Width-width)/2, y+(current.Height-height)/2)
+	log.Printf("moved to %s", monitor.GetName())
+}
+
+func (d *display) setVSync(vsync bool) {
+	d.VSync = vsync
+	if vsync {
+		glfw.SwapInterval(1)
+	} else {
+		glfw.SwapInterval(0)
+	}
+}
+
+func (d *display) nextFrameCap() {
+	next := frameCaps[0]
+	for _, frameCap := range frameCaps {
+		if frameCap > d.FrameCap {
+			next = frameCap
+			break
+		}
+	}
+	d.FrameCap = next
+	d.nextFrame = 0
+	if next == 0 {
+		log.Printf("frame cap off")
+	} else {
+		log.Printf("frame cap %v fps", next)
+	}
+}
+
+func (d *display) keyCallback(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+	if action != glfw.Press {
+		return
+	}
+
+	switch {
+	case key == glfw.KeyF11:
+		mode := d.Fullscreen
+		if mode == app.Windowed {
+			mode = app.Borderless
+		}
+		d.toggleFullscreen(mode)
+	case key == glfw.KeyEnter && mods&glfw.ModAlt != 0:
+		d.toggleFullscreen(app.Exclusive)
+	case key == glfw.KeyF10:
+		d.nextMonitor()
+	case key == glfw.KeyF9:
+		d.setVSync(!d.VSync)
+		log.Printf("vsync %v", d.VSync)
+	case key == glfw.KeyF8:
+		d.nextFrameCap()
+	}
+}
+
+// restore - Leaves fullscreen so the monitor gets its desktop mode back
+func (d *display) restore() {
+	if d.fullscreen != app.Windowed {
+		d.setFullscreen(app.Windowed)
+	}
+}
+
+// limit - Sleeps until the frame cap allows the next frame to start
+func (d *display) limit() {
+	if d.FrameCap <= 0 {
+		return
+	}
+
+	now := glfw.GetTime()
+	if d.nextFrame == 0 || now-d.nextFrame > 1 {
+		// First frame, or far behind after a stall: start counting from here
+		d.nextFrame = now
+	}
+	d.nextFrame += 1 / d.FrameCap
+	if wait := d.nextFrame - now; wait > 0 {
+		time.Sleep(time.Duration(wait * float64(time.Second)))
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
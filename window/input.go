@@ -0,0 +1,68 @@
+package window
+
+import (
+	"github.com/go-gl/glfw/v3.2/glfw"
+	"github.com/thegrandpackard/gogl/controls"
+)
+
+// windowInput - The keyboard, mouse and cursor of a GLFW window and the joysticks GLFW knows, as
+// devices and apps read them. Keys and mouse buttons are numbered alike on both sides.
+type windowInput struct {
+	window *glfw.Window
+}
+
+func (w *windowInput) Key(key controls.Key) bool {
+	return w.window.GetKey(glfw.Key(key)) == glfw.Press
+}
+
+func (w *windowInput) MouseButton(button controls.MouseButton) bool {
+	return w.window.GetMouseButton(glfw.MouseButton(button)) == glfw.Press
+}
+
+func (w *windowInput) Cursor() (float64, float64) {
+	return w.window.GetCursorPos()
+}
+
+func (w *windowInput) SetScrollCallback(scroll func(x, y float64)) {
+	if scroll == nil {
+		w.window.SetScrollCallback(nil)
+		return
+	}
+	w.window.SetScrollCallback(func(_ *glfw.Window, xoff float64, yoff float64) {
+		scroll(xoff, yoff)
+	})
+}
+
+func (w *windowInput) SetCursorFree(free bool) {
+	if free {
+		w.window.SetInputMode(glfw.CursorMode, glfw.CursorNormal)
+	} else {
+		w.window.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
+	}
+}
+
+func (w *windowInput) JoystickPresent(joy int) bool {
+	return glfw.JoystickPresent(glfw.Joystick(joy))
+}
+
+func (w *windowInput) JoystickName(joy int) string {
+	return glfw.GetJoystickName(glfw.Joystick(joy))
+}
+
+func (w *windowInput) JoystickAxes(joy int) []float32 {
+	return glfw.GetJoystickAxes(glfw.Joystick(joy))
+}
+
+func (w *windowInput) JoystickButtons(joy int) []byte {
+	return glfw.GetJoystickButtons(glfw.Joystick(joy))
+}
+
+func (w *windowInput) SetJoystickCallback(changed func()) {
+	if changed == nil {
+		glfw.SetJoystickCallback(nil)
+		return
+	}
+	glfw.SetJoystickCallback(func(joy, event int) {
+		changed()
+	})
+}
@@ -0,0 +1,265 @@
+// Package window runs apps in a GLFW window: it owns the window, the GL context and the main
+// loop so demos only have to describe what they draw. It is the interactive counterpart of the
+// headless runner.
+package window
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+	"github.com/thegrandpackard/gogl/app"
+	"github.com/thegrandpackard/gogl/controls"
+	"github.com/thegrandpackard/gogl/gl"
+	"github.com/thegrandpackard/gogl/helpers"
+)
+
+func init() {
+	// GLFW event handling must run on the main OS thread
+	runtime.LockOSThread()
+}
+
+// Run - Creates the window, runs application until the window is closed or Escape is pressed,
+// then tears everything down again. It has to be called from the main goroutine.
+func Run(application app.App, config app.Config) error {
+	if err := loadGamepadMappings(config.GamepadMappings); err != nil {
+		return err
+	}
+	if config.Record != "" && config.Replay != "" {
+		return fmt.Errorf("cannot record and replay at the same time")
+	}
+	if config.Record != "" && config.TimeStep <= 0 {
+		return fmt.Errorf("recording needs a fixed time step")
+	}
+
+	if err := glfw.Init(); err != nil {
+		return fmt.Errorf("failed to initialize glfw: %v", err)
+	}
+	defer glfw.Terminate()
+
+	glfw.WindowHint(glfw.Samples, config.Samples)
+
+	window, err := createWindow(config.Width, config.Height, config.Title, config.Profiles)
+	if err != nil {
+		return err
+	}
+	defer window.Destroy()
+
+	version := gl.GoStr(gl.GetString(gl.VERSION))
+	fmt.Println("OpenGL version", version)
+
+	// Report GL errors and warnings along with the Go stack that caused them
+	helpers.EnableDebugOutput(helpers.DefaultDebugFilter)
+
+	placeWindow(config.State, window)
+
+	display := newDisplay(window, config.Display)
+	// Keep the placement the window has after leaving fullscreen
+	defer keepWindow(config.State, window)
+	defer display.restore()
+	window.SetKeyCallback(display.keyCallback)
+
+	// Ensure we can capture the escape key being pressed below
+	window.SetInputMode(glfw.StickyKeysMode, glfw.True)
+
+	input := &windowInput{window: window}
+	if windowApp, ok := application.(app.WindowApp); ok {
+		windowApp.SetWindow(input)
+	}
+
+	device := controls.NewDevice(input)
+	device.Attach()
+	defer device.Detach()
+	if config.Replay != "" {
+		if config.TimeStep, err = replay(device, config.Replay); err != nil {
+			return err
+		}
+	}
+	if config.Record != "" {
+		stop, err := record(device, config.Record, config.TimeStep)
+		if err != nil {
+			return err
+		}
+		defer stop()
+	}
+	if inputApp, ok := application.(app.InputApp); ok {
+		inputApp.SetInput(device)
+	}
+	if cacheUser, ok := application.(app.ProgramCacheUser); ok && config.ProgramCache != "" {
+		cacheUser.SetProgramCache(helpers.NewProgramCache(config.ProgramCache))
+	}
+
+	captures, err := newCaptures(window, config)
+	if err != nil {
+		return err
+	}
+	defer captures.close()
+
+	if hotkeyApp, ok := application.(app.HotkeyApp); ok {
+		next := window.SetKeyCallback(nil)
+		window.SetKeyCallback(func(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+			if next != nil {
+				next(w, key, scancode, action, mods)
+			}
+			if action == glfw.Press {
+				hotkeyApp.Hotkey(controls.Key(key))
+				// Hotkeys like switching demos change the title
+				window.SetTitle(title(application, config))
+			}
+		})
+	}
+
+	width, height := window.GetFramebufferSize()
+	resize(application, width, height)
+	window.SetFramebufferSizeCallback(func(w *glfw.Window, width int, height int) {
+		resize(application, width, height)
+	})
+
+	// Everything the app created has to be gone before the context is destroyed
+	defer func() {
+		if leaks := helpers.ReportLeaks(); leaks > 0 {
+			log.Printf("%d GL object(s) were never released", leaks)
+		}
+	}()
+	defer application.Shutdown()
+	if err := application.Init(); err != nil {
+		return err
+	}
+
+	loop(window, display, device, captures, application, config)
+	return nil
+}
+
+func loop(window *glfw.Window, display *display, device *controls.Device, captures *captures, application app.App, config app.Config) {
+	lastTime := glfw.GetTime()
+	accumulator := 0.0
+
+	// The frame rate is shown in the title, counted over about a second
+	frames, countStart := 0, lastTime
+
+	for !window.ShouldClose() && window.GetKey(glfw.KeyEscape) != glfw.Press {
+		currentTime := glfw.GetTime()
+		frameTime := currentTime - lastTime
+		lastTime = currentTime
+
+		alpha := 1.0
+		if captures.recording() {
+			device.Poll()
+			application.Update(config.TimeStep)
+			accumulator = 0
+		} else if config.TimeStep <= 0 {
+			device.Poll()
+			application.Update(frameTime)
+		} else {
+			accumulator += frameTime
+			for updates := 0; accumulator >= config.TimeStep; updates++ {
+				if config.MaxUpdates > 0 && updates == config.MaxUpdates {
+					accumulator = 0
+					break
+				}
+				device.Poll()
+				application.Update(config.TimeStep)
+				accumulator -= config.TimeStep
+			}
+			alpha = accumulator / config.TimeStep
+		}
+
+		application.Render(alpha)
+		if !captures.frame(window) {
+			window.SetShouldClose(true)
+		}
+
+		// Maintenance
+		window.SwapBuffers()
+		display.limit()
+		glfw.PollEvents()
+
+		frames++
+		if elapsed := currentTime - countStart; elapsed >= 1 {
+			window.SetTitle(fmt.Sprintf("%s - %.0f fps", title(application, config), float64(frames)/elapsed))
+			frames, countStart = 0, currentTime
+		}
+	}
+}
+
+// title - The title of application, which may change while it runs
+func title(application app.App, config app.Config) string {
+	if titled, ok := application.(interface{ Title() string }); ok {
+		return titled.Title()
+	}
+	return config.Title
+}
+
+// resize - Makes the viewport cover the framebuffer and tells the app about it
+func resize(application app.App, width, height int) {
+	// Minimized windows have an empty framebuffer, keep the last size until they come back
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	gl.Viewport(0, 0, int32(width), int32(height))
+	if resizer, ok := application.(app.Resizer); ok {
+		resizer.Resize(width, height)
+	}
+}
+
+// loadGamepadMappings - Adds the mappings SDL would: those in the SDL_GAMECONTROLLERCONFIG
+// environment variable, then those of file
+func loadGamepadMappings(file string) error {
+	if env := os.Getenv("SDL_GAMECONTROLLERCONFIG"); env != "" {
+		if _, err := controls.AddMappings(strings.NewReader(env)); err != nil {
+			return fmt.Errorf("SDL_GAMECONTROLLERCONFIG: %v", err)
+		}
+	}
+	if file == "" {
+		return nil
+	}
+	added, err := controls.AddMappingsFromFile(file)
+	if err != nil {
+		return err
+	}
+	log.Printf("loaded %d gamepad mapping(s) from %s", added, file)
+	return nil
+}
+
+// replay - Plays the recording in file back through device and returns its time step
+func replay(device *controls.Device, file string) (float64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	timeStep, err := device.Replay(f)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", file, err)
+	}
+	log.Printf("replaying %s at %g updates per second", file, 1/timeStep)
+	return timeStep, nil
+}
+
+// record - Records the input of device to file until stop is called
+func record(device *controls.Device, file string, timeStep float64) (stop func(), err error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := device.Record(f, timeStep); err != nil {
+		f.Close()
+		return nil, err
+	}
+	log.Printf("recording input to %s", file)
+
+	return func() {
+		err := device.StopRecording()
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			log.Printf("failed to write %s: %v", file, err)
+		}
+	}, nil
+}
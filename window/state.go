@@ -0,0 +1,34 @@
+package window
+
+import (
+	"log"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+	"github.com/thegrandpackard/gogl/app"
+)
+
+// placeWindow - Moves window back where s says it was, unless no monitor shows that place any more
+func placeWindow(s *app.State, window *glfw.Window) {
+	if s == nil || s.Window == nil {
+		return
+	}
+	for _, monitor := range glfw.GetMonitors() {
+		x, y := monitor.GetPos()
+		mode := monitor.GetVideoMode()
+		if s.Window.X >= x && s.Window.X < x+mode.Width && s.Window.Y >= y && s.Window.Y < y+mode.Height {
+			window.SetPos(s.Window.X, s.Window.Y)
+			return
+		}
+	}
+	log.Printf("the window's last position %d,%d is off every monitor, leaving it where it is", s.Window.X, s.Window.Y)
+}
+
+// keepWindow - Remembers in s where window is
+func keepWindow(s *app.State, window *glfw.Window) {
+	if s == nil {
+		return
+	}
+	x, y := window.GetPos()
+	width, height := window.GetSize()
+	s.Window = &app.WindowState{X: x, Y: y, Width: width, Height: height}
+}